@@ -0,0 +1,169 @@
+package tree
+
+import (
+	"iter"
+)
+
+type _AhoCorasickNode[T comparable] struct {
+	children map[T]int
+	// fail is the node of the longest proper suffix which is also a prefix of some pattern
+	fail int
+	// output is the nearest node on the fail chain having patterns, 0 if none
+	output int
+	// patterns are the ids of patterns ending at the node
+	patterns []int
+}
+
+// AhoCorasickMatch represents an occurrence of the pattern ID within text[Start:End].
+type AhoCorasickMatch struct {
+	ID         int
+	Start, End int
+}
+
+// AhoCorasick is a multi-pattern matching automaton.
+type AhoCorasick[T comparable] struct {
+	nodes    []_AhoCorasickNode[T]
+	patterns [][]T
+}
+
+// NewAhoCorasick creates the automaton of the patterns, the id of a pattern is its index.
+// Empty patterns never match.
+// @Complexity O(total length of patterns)
+func NewAhoCorasick[T comparable](patterns ...[]T) *AhoCorasick[T] {
+	ac := &AhoCorasick[T]{
+		nodes:    make([]_AhoCorasickNode[T], 1),
+		patterns: patterns,
+	}
+	for id, p := range patterns {
+		if len(p) > 0 {
+			ac.insert(id, p)
+		}
+	}
+	ac.build()
+	return ac
+}
+
+// NewAhoCorasickFromTrie creates the automaton of all sequences in the Trie,
+// the ids are assigned in the lexicographical order defined by cmp, so that they are stable across builds
+// from the same Trie. A nil cmp means random order, then the ids are only valid for this automaton
+// and must be mapped back with Pattern.
+// @Complexity O(total length of sequences), plus sorting the children if cmp is not nil
+func NewAhoCorasickFromTrie[T comparable](trie *Trie[T], cmp func(a, b T) int) *AhoCorasick[T] {
+	patterns := make([][]T, 0, trie.Size())
+	for seq := range trie.SortedWithPrefix(nil, cmp) {
+		patterns = append(patterns, seq)
+	}
+	return NewAhoCorasick(patterns...)
+}
+
+// Pattern returns the pattern of the id.
+func (ac *AhoCorasick[T]) Pattern(id int) []T { return ac.patterns[id] }
+
+// insert adds the pattern into the trie of the automaton.
+func (ac *AhoCorasick[T]) insert(id int, pattern []T) {
+	n := 0
+	for _, e := range pattern {
+		next, ok := ac.nodes[n].children[e]
+		if !ok {
+			if ac.nodes[n].children == nil {
+				ac.nodes[n].children = make(map[T]int)
+			}
+			next = len(ac.nodes)
+			ac.nodes[n].children[e] = next
+			ac.nodes = append(ac.nodes, _AhoCorasickNode[T]{})
+		}
+		n = next
+	}
+	ac.nodes[n].patterns = append(ac.nodes[n].patterns, id)
+}
+
+// build calculates the fail and output links by BFS.
+func (ac *AhoCorasick[T]) build() {
+	q := make([]int, 0, len(ac.nodes))
+	for _, child := range ac.nodes[0].children {
+		q = append(q, child)
+	}
+	for ; len(q) > 0; q = q[1:] {
+		u := q[0]
+		for e, v := range ac.nodes[u].children {
+			fail := ac.next(ac.nodes[u].fail, e)
+			ac.nodes[v].fail = fail
+			if len(ac.nodes[fail].patterns) > 0 {
+				ac.nodes[v].output = fail
+			} else {
+				ac.nodes[v].output = ac.nodes[fail].output
+			}
+			q = append(q, v)
+		}
+	}
+}
+
+// next returns the node after reading e at the node n.
+func (ac *AhoCorasick[T]) next(n int, e T) int {
+	for {
+		if child, ok := ac.nodes[n].children[e]; ok {
+			return child
+		}
+		if n == 0 {
+			return 0
+		}
+		n = ac.nodes[n].fail
+	}
+}
+
+// emit yields the matches ending at the node n, end is the exclusive end position.
+func (ac *AhoCorasick[T]) emit(n, end int, yield func(AhoCorasickMatch) bool) bool {
+	for len(ac.nodes[n].patterns) == 0 && n != 0 {
+		n = ac.nodes[n].output
+	}
+	for ; n != 0; n = ac.nodes[n].output {
+		for _, id := range ac.nodes[n].patterns {
+			if !yield(AhoCorasickMatch{ID: id, Start: end - len(ac.patterns[id]), End: end}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FindAll returns an iterator over all matches in the text ordered by end position,
+// matches with the same end are ordered from the longest.
+// @Complexity O(len(text) + number of matches)
+func (ac *AhoCorasick[T]) FindAll(text []T) iter.Seq[AhoCorasickMatch] {
+	return func(yield func(AhoCorasickMatch) bool) {
+		ac.Matcher().Feed(text)(yield)
+	}
+}
+
+// Matcher creates a streaming matcher starting at position 0.
+func (ac *AhoCorasick[T]) Matcher() *AhoCorasickMatcher[T] {
+	return &AhoCorasickMatcher[T]{ac: ac}
+}
+
+// AhoCorasickMatcher matches the input fed chunk by chunk,
+// so that patterns across chunk boundaries are found.
+type AhoCorasickMatcher[T comparable] struct {
+	ac *AhoCorasick[T]
+	// state is the current node
+	state int
+	// offset is the count of consumed elements
+	offset int
+}
+
+// Feed returns an iterator over the matches ending within the chunk,
+// the positions are counted from the beginning of the stream.
+// The chunk is consumed as the iterator runs, stopping early leaves the rest unconsumed.
+func (m *AhoCorasickMatcher[T]) Feed(chunk []T) iter.Seq[AhoCorasickMatch] {
+	return func(yield func(AhoCorasickMatch) bool) {
+		for _, e := range chunk {
+			m.state = m.ac.next(m.state, e)
+			m.offset++
+			if !m.ac.emit(m.state, m.offset, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Reset moves the matcher back to the beginning of a new stream.
+func (m *AhoCorasickMatcher[T]) Reset() { m.state, m.offset = 0, 0 }
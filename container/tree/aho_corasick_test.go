@@ -0,0 +1,98 @@
+package tree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sortMatches(ms []AhoCorasickMatch) {
+	slices.SortFunc(ms, func(a, b AhoCorasickMatch) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.End, b.End), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+func naiveMatches(patterns [][]byte, text []byte) []AhoCorasickMatch {
+	var ms []AhoCorasickMatch
+	for id, p := range patterns {
+		if len(p) == 0 {
+			continue
+		}
+		for i := 0; i+len(p) <= len(text); i++ {
+			if slices.Equal(p, text[i:i+len(p)]) {
+				ms = append(ms, AhoCorasickMatch{ID: id, Start: i, End: i + len(p)})
+			}
+		}
+	}
+	sortMatches(ms)
+	return ms
+}
+
+func TestAhoCorasick(t *testing.T) {
+	patterns := [][]byte{[]byte("he"), []byte("she"), []byte("his"), []byte("hers"), []byte("s"), []byte(""), []byte("he")}
+	ac := NewAhoCorasick(patterns...)
+	text := []byte("ushers said his hershe")
+	got := slices.Collect(ac.FindAll(text))
+	for i := 1; i < len(got); i++ {
+		require.LessOrEqual(t, got[i-1].End, got[i].End)
+	}
+	sortMatches(got)
+	require.Equal(t, naiveMatches(patterns, text), got)
+	require.Equal(t, []byte("hers"), ac.Pattern(3))
+
+	r := rand.New(rand.NewSource(1))
+	random := func(n int) []byte {
+		s := make([]byte, n)
+		for i := range s {
+			s[i] = 'a' + byte(r.Intn(3))
+		}
+		return s
+	}
+	for range 50 {
+		patterns = patterns[:0]
+		for range 1 + r.Intn(8) {
+			patterns = append(patterns, random(1+r.Intn(4)))
+		}
+		text = random(r.Intn(60))
+		got = slices.Collect(NewAhoCorasick(patterns...).FindAll(text))
+		sortMatches(got)
+		require.Equal(t, naiveMatches(patterns, text), got)
+	}
+}
+
+func TestAhoCorasick_Stream(t *testing.T) {
+	trie := NewTrie[byte]()
+	for _, p := range []string{"needle", "ed", "le"} {
+		trie.Add(1, []byte(p)...)
+	}
+	ac := NewAhoCorasickFromTrie(trie, cmp.Compare[byte])
+	// the ids follow the order of cmp
+	for id, p := range []string{"ed", "le", "needle"} {
+		require.Equal(t, []byte(p), ac.Pattern(id))
+	}
+	text := []byte("haystack needle and neednot needle")
+	expect := slices.Collect(ac.FindAll(text))
+	require.Len(t, expect, 7)
+	// the iterator can be reused
+	require.Equal(t, expect, slices.Collect(ac.FindAll(text)))
+	for _, m := range expect {
+		require.Equal(t, ac.Pattern(m.ID), text[m.Start:m.End])
+	}
+
+	m := ac.Matcher()
+	got := make([]AhoCorasickMatch, 0)
+	// chunks split the patterns
+	for _, chunk := range [][]byte{text[:11], text[11:13], text[13:30], text[30:]} {
+		got = append(got, slices.Collect(m.Feed(chunk))...)
+	}
+	require.Equal(t, expect, got)
+
+	m.Reset()
+	for range m.Feed(text) {
+		break
+	}
+	require.Equal(t, 13, m.offset)
+}
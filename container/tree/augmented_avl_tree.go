@@ -0,0 +1,121 @@
+package tree
+
+import (
+	"iter"
+)
+
+// _AugmentedEntry is the value stored in the nodes of AugmentedAvlTree,
+// agg is the aggregate of the subtree that the node is the root
+type _AugmentedEntry[V, A any] struct {
+	val V
+	agg A
+}
+
+// AugmentedAvlTree is an AVL tree maintaining a user-defined aggregate for every subtree,
+// the aggregate is defined by a monoid (identity, combine) over the measure of each element.
+type AugmentedAvlTree[K, V, A any] struct {
+	avl      *AvlTree[K, _AugmentedEntry[V, A]]
+	identity A
+	combine  func(A, A) A
+	measure  func(K, V) A
+}
+
+// NewAugmentedAvlTree creates an empty augmented AVL tree.
+// @Param identity is the identity element of combine.
+// @Param combine must be associative, it is applied in key order so needs not be commutative.
+// @Param measure maps an element to its aggregate.
+func NewAugmentedAvlTree[K, V, A any](cmp func(a, b K) int, identity A, combine func(x, y A) A, measure func(key K, val V) A) *AugmentedAvlTree[K, V, A] {
+	t := &AugmentedAvlTree[K, V, A]{
+		avl:      NewAvlTree[K, _AugmentedEntry[V, A]](cmp),
+		identity: identity,
+		combine:  combine,
+		measure:  measure,
+	}
+	t.avl.augment = t.maintain
+	return t
+}
+
+// maintain recomputes the aggregate of the node from its children
+func (t *AugmentedAvlTree[K, V, A]) maintain(n *_AvlTreeNode[K, _AugmentedEntry[V, A]]) {
+	n.val.agg = t.combine(t.combine(t.aggOf(n.left), t.measure(n.key, n.val.val)), t.aggOf(n.right))
+}
+
+func (t *AugmentedAvlTree[K, V, A]) aggOf(n *_AvlTreeNode[K, _AugmentedEntry[V, A]]) A {
+	if n == nil {
+		return t.identity
+	}
+	return n.val.agg
+}
+
+// Size returns the total number of elements.
+// Complexity: O(1)
+func (t *AugmentedAvlTree[K, V, A]) Size() int { return t.avl.Size() }
+
+// Get searches for a value and returns (value, true) if found
+// Complexity: O(log(n))
+func (t *AugmentedAvlTree[K, V, A]) Get(key K) (V, bool) {
+	e, ok := t.avl.Get(key)
+	return e.val, ok
+}
+
+// Contains returns existence of a value
+// Complexity: O(log(n))
+func (t *AugmentedAvlTree[K, V, A]) Contains(key K) bool { return t.avl.Contains(key) }
+
+// Rank finds the k-th smallest element (1-based index)
+// Complexity: O(log(n))
+func (t *AugmentedAvlTree[K, V, A]) Rank(k int) (V, bool) {
+	e, ok := t.avl.Rank(k)
+	return e.val, ok
+}
+
+// Set inserts a value or updates if exists through compare function
+// Complexity: O(log(n))
+func (t *AugmentedAvlTree[K, V, A]) Set(key K, val V) {
+	t.avl.Set(key, _AugmentedEntry[V, A]{val: val})
+}
+
+// Remove deletes a value from the tree if exists
+// Complexity: O(log(n))
+func (t *AugmentedAvlTree[K, V, A]) Remove(key K) { t.avl.Remove(key) }
+
+// AggregateAll returns the aggregate of all elements
+// Complexity: O(1)
+func (t *AugmentedAvlTree[K, V, A]) AggregateAll() A { return t.aggOf(t.avl.root) }
+
+// Aggregate returns the aggregate of the elements whose keys are within the range [lo, hi]
+// Complexity: O(log(n))
+func (t *AugmentedAvlTree[K, V, A]) Aggregate(lo, hi K) A {
+	return t.aggregate(t.avl.root, lo, hi, true, true)
+}
+
+// aggregate folds the subtree, checkLo and checkHi tell whether the bounds still cut the subtree
+func (t *AugmentedAvlTree[K, V, A]) aggregate(n *_AvlTreeNode[K, _AugmentedEntry[V, A]], lo, hi K, checkLo, checkHi bool) A {
+	if n == nil {
+		return t.identity
+	}
+	if !checkLo && !checkHi {
+		return n.val.agg
+	}
+	if checkLo && t.avl.cmp(n.key, lo) < 0 {
+		return t.aggregate(n.right, lo, hi, checkLo, checkHi)
+	}
+	if checkHi && t.avl.cmp(n.key, hi) > 0 {
+		return t.aggregate(n.left, lo, hi, checkLo, checkHi)
+	}
+	// n is in range, so the left subtree is below hi and the right subtree is above lo
+	ret := t.aggregate(n.left, lo, hi, checkLo, false)
+	ret = t.combine(ret, t.measure(n.key, n.val.val))
+	return t.combine(ret, t.aggregate(n.right, lo, hi, false, checkHi))
+}
+
+// Iter provides an in-order traversal iterator
+func (t *AugmentedAvlTree[K, V, A]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, e := range t.avl.Iter() {
+			if !yield(k, e.val) {
+				return
+			}
+		}
+	}
+}
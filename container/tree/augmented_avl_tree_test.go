@@ -0,0 +1,72 @@
+package tree
+
+import (
+	"cmp"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAugmentedAvlTree_Sum(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewAugmentedAvlTree(cmp.Compare[int], 0,
+		func(x, y int) int { return x + y },
+		func(_ int, v int) int { return v })
+	expect := make(map[int]int)
+	for i := range 500 {
+		key := r.Intn(64)
+		if i%3 == 2 {
+			tree.Remove(key)
+			delete(expect, key)
+		} else {
+			tree.Set(key, i)
+			expect[key] = i
+		}
+		checkAvlBalance(t, tree.avl.root, i)
+		require.Equal(t, len(expect), tree.Size(), i)
+		sum := 0
+		for _, v := range expect {
+			sum += v
+		}
+		require.Equal(t, sum, tree.AggregateAll(), i)
+	}
+	for lo := -1; lo <= 64; lo++ {
+		for hi := lo - 1; hi <= 64; hi++ {
+			sum := 0
+			for k, v := range expect {
+				if lo <= k && k <= hi {
+					sum += v
+				}
+			}
+			require.Equal(t, sum, tree.Aggregate(lo, hi), lo, hi)
+		}
+	}
+	for k, v := range tree.Iter() {
+		require.Equal(t, expect[k], v)
+		val, ok := tree.Get(k)
+		require.True(t, ok)
+		require.Equal(t, v, val)
+		require.True(t, tree.Contains(k))
+	}
+	minK, _, _ := tree.avl.Min()
+	v, ok := tree.Rank(1)
+	require.True(t, ok)
+	require.Equal(t, expect[minK], v)
+}
+
+func TestAugmentedAvlTree_Order(t *testing.T) {
+	// concatenation is not commutative, the aggregate must follow the key order
+	tree := NewAugmentedAvlTree(cmp.Compare[int], "",
+		func(x, y string) string { return x + y },
+		func(k int, _ struct{}) string { return strconv.Itoa(k) })
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		tree.Set(k, struct{}{})
+	}
+	require.Equal(t, "123456789", tree.AggregateAll())
+	require.Equal(t, "3456", tree.Aggregate(3, 6))
+	require.Equal(t, "", tree.Aggregate(6, 3))
+	tree.Remove(5)
+	require.Equal(t, "346", tree.Aggregate(3, 6))
+}
@@ -30,6 +30,13 @@ func (n *_AvlTreeNode[K, V]) getHeight() int {
 	return n.height
 }
 
+func (n *_AvlTreeNode[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
 // getFactor returns the difference between left child height and right child height
 func (n *_AvlTreeNode[K, V]) getFactor() int { return n.left.getHeight() - n.right.getHeight() }
 
@@ -130,6 +137,48 @@ func (n *_AvlTreeNode[K, V]) maintain() {
 	}
 }
 
+// join links l, mid and r into a balanced tree,
+// all keys in l must be less than mid's key and all keys in r must be greater
+// Complexity: O(|height(l) - height(r)|)
+func join[K, V any](l, mid, r *_AvlTreeNode[K, V]) *_AvlTreeNode[K, V] {
+	// descend along the right spine of the higher left tree
+	if l.getHeight() > r.getHeight()+1 {
+		l.right = join(l.right, mid, r)
+		return l.reBalance()
+	}
+	// descend along the left spine of the higher right tree
+	if r.getHeight() > l.getHeight()+1 {
+		r.left = join(l, mid, r.left)
+		return r.reBalance()
+	}
+	mid.left, mid.right = l, r
+	mid.maintain()
+	return mid
+}
+
+// removeMin detaches the leftmost node and returns the remaining subtree and the detached node
+func (n *_AvlTreeNode[K, V]) removeMin() (rest, minNode *_AvlTreeNode[K, V]) {
+	if n.left == nil {
+		rest, n.right = n.right, nil
+		return rest, n
+	}
+	n.left, minNode = n.left.removeMin()
+	return n.reBalance(), minNode
+}
+
+// buildSorted builds a perfectly balanced subtree from sorted keys and values
+func buildSorted[K, V any](keys []K, vals []V) *_AvlTreeNode[K, V] {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	n := newNode(keys[mid], vals[mid])
+	n.left = buildSorted(keys[:mid], vals[:mid])
+	n.right = buildSorted(keys[mid+1:], vals[mid+1:])
+	n.maintain()
+	return n
+}
+
 func (n *_AvlTreeNode[K, V]) inorder(yield func(K, V) bool) bool {
 	if n.left != nil && !n.left.inorder(yield) {
 		return false
@@ -143,10 +192,45 @@ func (n *_AvlTreeNode[K, V]) inorder(yield func(K, V) bool) bool {
 	return true
 }
 
+// reverseInorder traverses the subtree in descending order
+func (n *_AvlTreeNode[K, V]) reverseInorder(yield func(K, V) bool) bool {
+	if n.right != nil && !n.right.reverseInorder(yield) {
+		return false
+	}
+	if !yield(n.key, n.val) {
+		return false
+	}
+	if n.left != nil && !n.left.reverseInorder(yield) {
+		return false
+	}
+	return true
+}
+
+// rangeInorder traverses the keys satisfying both aboveLo and belowHi in ascending order,
+// subtrees entirely outside the bounds are skipped
+func (n *_AvlTreeNode[K, V]) rangeInorder(aboveLo, belowHi func(K) bool, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	geLo, leHi := aboveLo(n.key), belowHi(n.key)
+	if geLo && !n.left.rangeInorder(aboveLo, belowHi, yield) {
+		return false
+	}
+	if geLo && leHi && !yield(n.key, n.val) {
+		return false
+	}
+	if leHi && !n.right.rangeInorder(aboveLo, belowHi, yield) {
+		return false
+	}
+	return true
+}
+
 // AvlTree represents a self-balancing binary search tree using AVL algorithm
 type AvlTree[K, V any] struct {
 	root *_AvlTreeNode[K, V]
 	cmp  func(a, b K) int
+	// augment recomputes extra data kept in the node from its children, nil if not needed
+	augment func(n *_AvlTreeNode[K, V])
 }
 
 // NewAvlTree creates an empty AVL tree with a given comparison function
@@ -157,6 +241,24 @@ func NewAvlTree[K, V any](cmp func(a, b K) int) *AvlTree[K, V] {
 	}
 }
 
+// NewAvlTreeFromSorted creates an AVL tree from keys in strictly ascending order and their values.
+// It panics when the lengths of keys and vals differ or keys are not strictly ascending.
+// Complexity: O(n)
+func NewAvlTreeFromSorted[K, V any](cmp func(a, b K) int, keys []K, vals []V) *AvlTree[K, V] {
+	if len(keys) != len(vals) {
+		panic("keys and vals have different lengths")
+	}
+	for i := 1; i < len(keys); i++ {
+		if cmp(keys[i-1], keys[i]) >= 0 {
+			panic("keys are not strictly ascending")
+		}
+	}
+	return &AvlTree[K, V]{
+		root: buildSorted(keys, vals),
+		cmp:  cmp,
+	}
+}
+
 // Size returns the total number of elements.
 // Complexity: O(1)
 func (avl *AvlTree[K, V]) Size() int {
@@ -193,16 +295,20 @@ func (avl *AvlTree[K, V]) Contains(key K) bool {
 // Rank finds the k-th smallest element (1-based index)
 // Complexity: O(log(n))
 func (avl *AvlTree[K, V]) Rank(k int) (val V, ok bool) {
+	_, val, ok = avl.Select(k)
+	return val, ok
+}
+
+// Select finds the k-th smallest key and its value (1-based index)
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Select(k int) (key K, val V, ok bool) {
 	if k <= 0 || k > avl.Size() {
-		return val, false
+		return key, val, false
 	}
 	for n := avl.root; ; {
-		leftSize := 0
-		if n.left != nil {
-			leftSize = n.left.size
-		}
+		leftSize := n.left.getSize()
 		if k == leftSize+1 {
-			return n.val, true
+			return n.key, n.val, true
 		}
 		if k <= leftSize {
 			n = n.left
@@ -213,26 +319,191 @@ func (avl *AvlTree[K, V]) Rank(k int) (val V, ok bool) {
 	}
 }
 
+// IndexOf returns the 1-based rank of the key and whether it exists
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) IndexOf(key K) (int, bool) {
+	idx := 0
+	for n := avl.root; n != nil; {
+		c := avl.cmp(key, n.key)
+		if c == 0 {
+			return idx + n.left.getSize() + 1, true
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			idx += n.left.getSize() + 1
+			n = n.right
+		}
+	}
+	return 0, false
+}
+
+// CountLess returns the number of keys strictly less than the given key
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) CountLess(key K) int { return avl.countLess(key, false) }
+
+// CountRange returns the number of keys within the range [lo, hi]
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) CountRange(lo, hi K) int {
+	if avl.cmp(lo, hi) > 0 {
+		return 0
+	}
+	return avl.countLess(hi, true) - avl.countLess(lo, false)
+}
+
+// countLess counts the keys less than (or equal to if inclusive) the given key
+func (avl *AvlTree[K, V]) countLess(key K, inclusive bool) int {
+	cnt := 0
+	for n := avl.root; n != nil; {
+		c := avl.cmp(key, n.key)
+		if c > 0 || c == 0 && inclusive {
+			cnt += n.left.getSize() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return cnt
+}
+
+// Min returns the smallest key and its value
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Min() (key K, val V, ok bool) {
+	n := avl.root
+	if n == nil {
+		return key, val, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.val, true
+}
+
+// Max returns the largest key and its value
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Max() (key K, val V, ok bool) {
+	n := avl.root
+	if n == nil {
+		return key, val, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.val, true
+}
+
+// Floor returns the largest key less than or equal to the given key
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Floor(key K) (K, V, bool) { return avl.lowerBound(key, true) }
+
+// Lower returns the largest key strictly less than the given key
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Lower(key K) (K, V, bool) { return avl.lowerBound(key, false) }
+
+// Ceiling returns the smallest key greater than or equal to the given key
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Ceiling(key K) (K, V, bool) { return avl.upperBound(key, true) }
+
+// Higher returns the smallest key strictly greater than the given key
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Higher(key K) (K, V, bool) { return avl.upperBound(key, false) }
+
+// lowerBound finds the largest node whose key is less than (or equal to if inclusive) the given key
+func (avl *AvlTree[K, V]) lowerBound(key K, inclusive bool) (k K, v V, ok bool) {
+	var found *_AvlTreeNode[K, V]
+	for n := avl.root; n != nil; {
+		c := avl.cmp(key, n.key)
+		if c == 0 && inclusive {
+			return n.key, n.val, true
+		}
+		if c > 0 {
+			found = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	if found == nil {
+		return k, v, false
+	}
+	return found.key, found.val, true
+}
+
+// upperBound finds the smallest node whose key is greater than (or equal to if inclusive) the given key
+func (avl *AvlTree[K, V]) upperBound(key K, inclusive bool) (k K, v V, ok bool) {
+	var found *_AvlTreeNode[K, V]
+	for n := avl.root; n != nil; {
+		c := avl.cmp(key, n.key)
+		if c == 0 && inclusive {
+			return n.key, n.val, true
+		}
+		if c < 0 {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	if found == nil {
+		return k, v, false
+	}
+	return found.key, found.val, true
+}
+
+// PopMin removes the smallest key and returns it with its value
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) PopMin() (key K, val V, ok bool) {
+	if key, val, ok = avl.Min(); ok {
+		avl.root = avl.remove(avl.root, key)
+	}
+	return key, val, ok
+}
+
+// PopMax removes the largest key and returns it with its value
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) PopMax() (key K, val V, ok bool) {
+	if key, val, ok = avl.Max(); ok {
+		avl.root = avl.remove(avl.root, key)
+	}
+	return key, val, ok
+}
+
 // Set inserts a value or updates if exists through compare function
 // Complexity: O(log(n))
 func (avl *AvlTree[K, V]) Set(key K, val V) { avl.root = avl.set(avl.root, key, val) }
 
 func (avl *AvlTree[K, V]) set(n *_AvlTreeNode[K, V], key K, val V) *_AvlTreeNode[K, V] {
 	if n == nil {
-		return newNode(key, val)
+		return avl.settle(newNode(key, val))
 	}
 	c := avl.cmp(key, n.key)
 	// renew value when equal
 	if c == 0 {
 		n.val = val
-		return n
+		return avl.settle(n)
 	}
 	if c < 0 {
 		n.left = avl.set(n.left, key, val)
 	} else {
 		n.right = avl.set(n.right, key, val)
 	}
-	return n.reBalance()
+	return avl.settle(n.reBalance())
+}
+
+// settle applies augment to the subtree root and its children,
+// which covers every node a rotation in reBalance may have moved
+func (avl *AvlTree[K, V]) settle(n *_AvlTreeNode[K, V]) *_AvlTreeNode[K, V] {
+	if avl.augment == nil {
+		return n
+	}
+	if n.left != nil {
+		avl.augment(n.left)
+	}
+	if n.right != nil {
+		avl.augment(n.right)
+	}
+	avl.augment(n)
+	return n
 }
 
 // Remove deletes a value from the tree if exists
@@ -274,7 +545,63 @@ func (avl *AvlTree[K, V]) remove(n *_AvlTreeNode[K, V], key K) *_AvlTreeNode[K,
 		// remove the successor
 		n.right = avl.remove(n.right, successor.key)
 	}
-	return n.reBalance()
+	return avl.settle(n.reBalance())
+}
+
+// RemoveAt deletes the k-th smallest element (1-based index) and returns it
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) RemoveAt(k int) (key K, val V, ok bool) {
+	if key, val, ok = avl.Select(k); ok {
+		avl.root = avl.remove(avl.root, key)
+	}
+	return key, val, ok
+}
+
+// Split moves the keys less than the given key into the first returned tree
+// and the rest into the second one, the receiver becomes empty
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Split(key K) (less, notLess *AvlTree[K, V]) {
+	l, r := avl.split(avl.root, key)
+	avl.root = nil
+	return &AvlTree[K, V]{root: l, cmp: avl.cmp}, &AvlTree[K, V]{root: r, cmp: avl.cmp}
+}
+
+func (avl *AvlTree[K, V]) split(n *_AvlTreeNode[K, V], key K) (l, r *_AvlTreeNode[K, V]) {
+	if n == nil {
+		return nil, nil
+	}
+	if avl.cmp(key, n.key) <= 0 {
+		l, r = avl.split(n.left, key)
+		return l, join(r, n, n.right)
+	}
+	l, r = avl.split(n.right, key)
+	return join(n.left, n, l), r
+}
+
+// Join moves all elements of other into the tree, other becomes empty.
+// The key ranges of two trees must be disjoint, otherwise it panics.
+// Complexity: O(log(n))
+func (avl *AvlTree[K, V]) Join(other *AvlTree[K, V]) {
+	if other.root == nil {
+		return
+	}
+	if avl.root == nil {
+		avl.root, other.root = other.root, nil
+		return
+	}
+	l, r := avl.root, other.root
+	lMax, _, _ := avl.Max()
+	rMin, _, _ := other.Min()
+	if avl.cmp(lMax, rMin) >= 0 {
+		l, r = r, l
+		lMax, _, _ = other.Max()
+		rMin, _, _ = avl.Min()
+		if avl.cmp(lMax, rMin) >= 0 {
+			panic("key ranges overlap")
+		}
+	}
+	r, mid := r.removeMin()
+	avl.root, other.root = join(l, mid, r), nil
 }
 
 // Iter provides an in-order traversal iterator
@@ -286,3 +613,40 @@ func (avl *AvlTree[K, V]) Iter() iter.Seq2[K, V] {
 		avl.root.inorder(yield)
 	}
 }
+
+// Backward provides a descending in-order traversal iterator
+func (avl *AvlTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if avl.root == nil {
+			return
+		}
+		avl.root.reverseInorder(yield)
+	}
+}
+
+// Range provides an ascending iterator over the keys between lo and hi,
+// loInclusive and hiInclusive determine whether the bounds themselves are included
+// Complexity: O(log(n) + m), m is the number of keys in range
+func (avl *AvlTree[K, V]) Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V] {
+	aboveLo := func(k K) bool {
+		c := avl.cmp(k, lo)
+		return c > 0 || c == 0 && loInclusive
+	}
+	belowHi := func(k K) bool {
+		c := avl.cmp(k, hi)
+		return c < 0 || c == 0 && hiInclusive
+	}
+	return func(yield func(K, V) bool) {
+		avl.root.rangeInorder(aboveLo, belowHi, yield)
+	}
+}
+
+// From provides an ascending iterator starting at the smallest key greater than or equal to the given key
+// Complexity: O(log(n) + m), m is the number of keys visited
+func (avl *AvlTree[K, V]) From(key K) iter.Seq2[K, V] {
+	aboveLo := func(k K) bool { return avl.cmp(k, key) >= 0 }
+	belowHi := func(K) bool { return true }
+	return func(yield func(K, V) bool) {
+		avl.root.rangeInorder(aboveLo, belowHi, yield)
+	}
+}
@@ -145,3 +145,294 @@ func TestAvlTree_IterBreak(t *testing.T) {
 		}
 	}
 }
+
+func TestAvlTree_Navigation(t *testing.T) {
+	avl := NewAvlTree[int, int](cmp.Compare)
+	_, _, ok := avl.Min()
+	require.False(t, ok)
+	_, _, ok = avl.Max()
+	require.False(t, ok)
+	_, _, ok = avl.Floor(1)
+	require.False(t, ok)
+	_, _, ok = avl.PopMin()
+	require.False(t, ok)
+
+	// keys: 0, 10, 20, ..., 90
+	for i := range 10 {
+		avl.Set(i*10, i)
+	}
+	k, v, ok := avl.Min()
+	require.True(t, ok)
+	require.Equal(t, 0, k)
+	require.Equal(t, 0, v)
+	k, v, ok = avl.Max()
+	require.True(t, ok)
+	require.Equal(t, 90, k)
+	require.Equal(t, 9, v)
+
+	for key := -5; key <= 95; key++ {
+		floor, ceiling := key/10*10, (key+9)/10*10
+		if key < 0 {
+			floor, ceiling = -10, 0
+		}
+		lower, higher := floor, ceiling
+		if key%10 == 0 {
+			lower, higher = key-10, key+10
+		}
+
+		k, v, ok = avl.Floor(key)
+		require.Equal(t, floor >= 0 && floor <= 90, ok, "floor", key)
+		if ok {
+			require.Equal(t, floor, k, "floor", key)
+			require.Equal(t, floor/10, v, "floor", key)
+		}
+		k, _, ok = avl.Lower(key)
+		require.Equal(t, lower >= 0 && lower <= 90, ok, "lower", key)
+		if ok {
+			require.Equal(t, lower, k, "lower", key)
+		}
+		k, v, ok = avl.Ceiling(key)
+		require.Equal(t, ceiling >= 0 && ceiling <= 90, ok, "ceiling", key)
+		if ok {
+			require.Equal(t, ceiling, k, "ceiling", key)
+			require.Equal(t, ceiling/10, v, "ceiling", key)
+		}
+		k, _, ok = avl.Higher(key)
+		require.Equal(t, higher >= 0 && higher <= 90, ok, "higher", key)
+		if ok {
+			require.Equal(t, higher, k, "higher", key)
+		}
+	}
+}
+
+func TestAvlTree_Pop(t *testing.T) {
+	avl := buildAvlTree([]int{5, 3, 8, 1, 4, 7, 9, 2, 6})
+	for i := 1; avl.Size() > 0; i++ {
+		k, v, ok := avl.PopMin()
+		require.True(t, ok)
+		require.Equal(t, i, k)
+		require.Equal(t, i, v)
+		k, v, ok = avl.PopMax()
+		if avl.Size() == 0 && !ok {
+			break
+		}
+		require.True(t, ok)
+		require.Equal(t, 10-i, k)
+		require.Equal(t, 10-i, v)
+	}
+	_, _, ok := avl.PopMax()
+	require.False(t, ok)
+	checkAvlTree(t, avl, []int{})
+}
+
+func TestAvlTree_Backward(t *testing.T) {
+	avl := buildAvlTree([]int{5, 3, 8, 1, 4, 7, 9, 2, 6})
+	keys := make([]int, 0, 9)
+	for k, v := range avl.Backward() {
+		require.Equal(t, k, v)
+		keys = append(keys, k)
+	}
+	require.Equal(t, []int{9, 8, 7, 6, 5, 4, 3, 2, 1}, keys)
+	// break
+	for k := range avl.Backward() {
+		if k == 5 {
+			break
+		}
+	}
+	// empty
+	for range NewAvlTree[int, int](cmp.Compare).Backward() {
+		require.Fail(t, "empty tree")
+	}
+}
+
+func TestAvlTree_Range(t *testing.T) {
+	// keys: 0, 2, 4, ..., 18
+	avl := NewAvlTree[int, int](cmp.Compare)
+	for i := range 10 {
+		avl.Set(i*2, i)
+	}
+	collect := func(seq func(func(int, int) bool)) []int {
+		keys := make([]int, 0)
+		for k, v := range seq {
+			require.Equal(t, k/2, v)
+			keys = append(keys, k)
+		}
+		return keys
+	}
+	for lo := -1; lo <= 20; lo++ {
+		for hi := lo; hi <= 20; hi++ {
+			for _, inc := range [][2]bool{{true, true}, {true, false}, {false, true}, {false, false}} {
+				expect := make([]int, 0)
+				for k := 0; k < 20; k += 2 {
+					if (k > lo || inc[0] && k == lo) && (k < hi || inc[1] && k == hi) {
+						expect = append(expect, k)
+					}
+				}
+				require.Equal(t, expect, collect(avl.Range(lo, hi, inc[0], inc[1])), lo, hi, inc)
+			}
+		}
+	}
+	// break
+	for k := range avl.Range(0, 18, true, true) {
+		if k == 8 {
+			break
+		}
+	}
+}
+
+func TestAvlTree_From(t *testing.T) {
+	avl := buildAvlTree([]int{2, 4, 6, 8, 10})
+	keys := make([]int, 0)
+	for k := range avl.From(5) {
+		keys = append(keys, k)
+	}
+	require.Equal(t, []int{6, 8, 10}, keys)
+	keys = keys[:0]
+	for k := range avl.From(4) {
+		keys = append(keys, k)
+		if k == 8 {
+			break
+		}
+	}
+	require.Equal(t, []int{4, 6, 8}, keys)
+	for range avl.From(11) {
+		require.Fail(t, "out of range")
+	}
+}
+
+func TestAvlTree_OrderStatistic(t *testing.T) {
+	// keys: 0, 2, 4, ..., 18
+	avl := NewAvlTree[int, int](cmp.Compare)
+	_, _, ok := avl.Select(1)
+	require.False(t, ok)
+	for i := range 10 {
+		avl.Set(i*2, i)
+	}
+	_, _, ok = avl.Select(0)
+	require.False(t, ok)
+	_, _, ok = avl.Select(11)
+	require.False(t, ok)
+	for i := range 10 {
+		k, v, ok := avl.Select(i + 1)
+		require.True(t, ok)
+		require.Equal(t, i*2, k)
+		require.Equal(t, i, v)
+
+		idx, ok := avl.IndexOf(i * 2)
+		require.True(t, ok)
+		require.Equal(t, i+1, idx)
+		_, ok = avl.IndexOf(i*2 + 1)
+		require.False(t, ok)
+	}
+	for key := -1; key <= 20; key++ {
+		require.Equal(t, min(max((key+1)/2, 0), 10), avl.CountLess(key), key)
+	}
+	for lo := -1; lo <= 20; lo++ {
+		for hi := -1; hi <= 20; hi++ {
+			expect := 0
+			for k := 0; k < 20; k += 2 {
+				if lo <= k && k <= hi {
+					expect++
+				}
+			}
+			require.Equal(t, expect, avl.CountRange(lo, hi), lo, hi)
+		}
+	}
+}
+
+func TestAvlTree_RemoveAt(t *testing.T) {
+	avl := buildAvlTree([]int{5, 3, 8, 1, 4, 7, 9, 2, 6})
+	_, _, ok := avl.RemoveAt(10)
+	require.False(t, ok)
+	k, v, ok := avl.RemoveAt(3)
+	require.True(t, ok)
+	require.Equal(t, 3, k)
+	require.Equal(t, 3, v)
+	checkAvlTree(t, avl, []int{1, 2, 4, 5, 6, 7, 8, 9})
+	k, _, ok = avl.RemoveAt(8)
+	require.True(t, ok)
+	require.Equal(t, 9, k)
+	checkAvlTree(t, avl, []int{1, 2, 4, 5, 6, 7, 8})
+}
+
+// checkAvlBalance validates the height, size and balance factor of every node
+func checkAvlBalance[K, V any](t *testing.T, n *_AvlTreeNode[K, V], msg ...any) {
+	if n == nil {
+		return
+	}
+	checkAvlBalance(t, n.left, msg...)
+	checkAvlBalance(t, n.right, msg...)
+	require.Equal(t, max(n.left.getHeight(), n.right.getHeight())+1, n.height, msg...)
+	require.Equal(t, n.left.getSize()+n.right.getSize()+1, n.size, msg...)
+	require.LessOrEqual(t, n.getFactor(), 1, msg...)
+	require.GreaterOrEqual(t, n.getFactor(), -1, msg...)
+}
+
+func TestNewAvlTreeFromSorted(t *testing.T) {
+	for n := range 20 {
+		keys := make([]int, n)
+		for i := range keys {
+			keys[i] = i
+		}
+		avl := NewAvlTreeFromSorted(cmp.Compare, keys, keys)
+		checkAvlBalance(t, avl.root, n)
+		checkAvlTree(t, avl, keys, n)
+	}
+	require.Panics(t, func() { NewAvlTreeFromSorted(cmp.Compare, []int{1, 2}, []int{1}) })
+	require.Panics(t, func() { NewAvlTreeFromSorted(cmp.Compare, []int{1, 1}, []int{1, 1}) })
+	require.Panics(t, func() { NewAvlTreeFromSorted(cmp.Compare, []int{2, 1}, []int{2, 1}) })
+}
+
+func TestAvlTree_Split(t *testing.T) {
+	elems := []int{8, 4, 12, 2, 6, 10, 14, 1, 3, 5, 7, 9, 11, 13, 15}
+	for key := 0; key <= 16; key++ {
+		avl := buildAvlTree(elems)
+		less, notLess := avl.Split(key)
+		require.Equal(t, 0, avl.Size())
+		expectLess, expectNotLess := make([]int, 0), make([]int, 0)
+		for i := 1; i <= 15; i++ {
+			if i < key {
+				expectLess = append(expectLess, i)
+			} else {
+				expectNotLess = append(expectNotLess, i)
+			}
+		}
+		checkAvlBalance(t, less.root, key)
+		checkAvlBalance(t, notLess.root, key)
+		checkAvlTree(t, less, expectLess, key)
+		checkAvlTree(t, notLess, expectNotLess, key)
+	}
+}
+
+func TestAvlTree_Join(t *testing.T) {
+	build := func(lo, hi int) *AvlTree[int, int] {
+		avl := NewAvlTree[int, int](cmp.Compare)
+		for i := lo; i < hi; i++ {
+			avl.Set(i, i)
+		}
+		return avl
+	}
+	expect := func(lo, hi int) []int {
+		ret := make([]int, 0, hi-lo)
+		for i := lo; i < hi; i++ {
+			ret = append(ret, i)
+		}
+		return ret
+	}
+	for mid := 0; mid <= 40; mid++ {
+		// other after
+		avl, other := build(0, mid), build(mid, 40)
+		avl.Join(other)
+		require.Equal(t, 0, other.Size())
+		checkAvlBalance(t, avl.root, mid)
+		checkAvlTree(t, avl, expect(0, 40), mid)
+		// other before
+		avl, other = build(mid, 40), build(0, mid)
+		avl.Join(other)
+		require.Equal(t, 0, other.Size())
+		checkAvlBalance(t, avl.root, mid)
+		checkAvlTree(t, avl, expect(0, 40), mid)
+	}
+	require.Panics(t, func() { build(0, 10).Join(build(5, 15)) })
+	require.Panics(t, func() { build(5, 15).Join(build(0, 10)) })
+}
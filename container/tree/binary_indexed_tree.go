@@ -1,10 +1,18 @@
 package tree
 
+import (
+	"math/bits"
+)
+
 // BinaryIndexedTree represents a Binary Indexed Tree (Fenwick Tree) data structure.
 type BinaryIndexedTree[T any] struct {
 	origin []T
 	tree   []T
 	merge  func(T, T) T
+	// inverse is optional, it makes merge a group operation
+	inverse func(T) T
+	// owned means origin is no longer the caller's slice, so it can grow in place
+	owned bool
 }
 
 // NewBinaryIndexedTree creates and initials a new Binary Indexed Tree
@@ -25,6 +33,17 @@ func NewBinaryIndexedTree[T any](origin []T, merge func(x T, y T) T) *BinaryInde
 	return bit
 }
 
+// NewGroupBinaryIndexedTree creates and initials a new Binary Indexed Tree
+// whose elements form a group, which allows range queries by prefix difference.
+// @Param merge defines how to merge two elements.
+// @Param inverse returns the inverse element, so that merge(x, inverse(x)) is the identity.
+// @Complexity O(n)
+func NewGroupBinaryIndexedTree[T any](origin []T, merge func(x T, y T) T, inverse func(x T) T) *BinaryIndexedTree[T] {
+	bit := NewBinaryIndexedTree(origin, merge)
+	bit.inverse = inverse
+	return bit
+}
+
 // Accumulate calculates the prefix accumulated value up to the given index i.
 // @Complexity O(log(n))
 func (bit *BinaryIndexedTree[T]) Accumulate(i int) T {
@@ -60,6 +79,85 @@ func (bit *BinaryIndexedTree[T]) Range(l, r int, consume func(e T)) {
 	}
 }
 
+// RangeQuery returns the merged value within the range [l, r].
+// @Complexity O(log(n)) if created with inverse, otherwise O(log^2(n))
+func (bit *BinaryIndexedTree[T]) RangeQuery(l, r int) T {
+	if bit.inverse != nil {
+		if l == 0 {
+			return bit.Accumulate(r)
+		}
+		return bit.merge(bit.Accumulate(r), bit.inverse(bit.Accumulate(l-1)))
+	}
+	var ret T
+	first := true
+	bit.Range(l, r, func(e T) {
+		if first {
+			ret, first = e, false
+			return
+		}
+		ret = bit.merge(ret, e)
+	})
+	return ret
+}
+
+// Set assigns the element at the given index i.
+// @Complexity O(log(n)) if created with inverse, otherwise O(log^2(n))
+func (bit *BinaryIndexedTree[T]) Set(i int, val T) {
+	if bit.inverse != nil {
+		delta := bit.merge(val, bit.inverse(bit.origin[i]))
+		bit.Renew(i, func(e T) T { return bit.merge(e, delta) })
+		return
+	}
+	bit.origin[i] = val
+	// rebuild every node covering i
+	for j := i + 1; j <= len(bit.tree); j += lowBit(j) {
+		bit.tree[j-1] = bit.rebuild(j)
+	}
+}
+
+// Append adds an element to the end, the tree grows by one.
+// The origin slice is copied at the first call, so the caller's slice is never written beyond its length.
+// @Complexity O(log(n)) amortized, O(n) at the first call
+func (bit *BinaryIndexedTree[T]) Append(val T) {
+	if !bit.owned {
+		bit.origin, bit.owned = append([]T(nil), bit.origin...), true
+	}
+	bit.origin = append(bit.origin, val)
+	bit.tree = append(bit.tree, val)
+	bit.tree[len(bit.tree)-1] = bit.rebuild(len(bit.tree))
+}
+
+// LowerBound returns the smallest index i whose prefix accumulated value satisfies reached,
+// or the size if there is none.
+// reached must be monotone, which means once true it keeps true as i grows.
+// @Complexity O(log(n))
+func (bit *BinaryIndexedTree[T]) LowerBound(reached func(T) bool) int {
+	var acc T
+	pos, has := 0, false
+	for step := 1 << bits.Len(uint(len(bit.tree))) >> 1; step > 0; step >>= 1 {
+		if pos+step > len(bit.tree) {
+			continue
+		}
+		next := bit.tree[pos+step-1]
+		if has {
+			next = bit.merge(acc, next)
+		}
+		if !reached(next) {
+			pos, acc, has = pos+step, next, true
+		}
+	}
+	return pos
+}
+
+// rebuild calculates the node j (1-based) from the original element and its child nodes.
+func (bit *BinaryIndexedTree[T]) rebuild(j int) T {
+	e := bit.origin[j-1]
+	for k := j - 1; k > j-lowBit(j); k &= k - 1 {
+		e = bit.merge(e, bit.tree[k-1])
+	}
+	return e
+}
+
 // GetOrigin returns the original element at the given index i.
 func (bit *BinaryIndexedTree[T]) GetOrigin(i int) T { return bit.origin[i] }
 
@@ -0,0 +1,103 @@
+package tree
+
+// BinaryIndexedTree2D represents a two-dimensional Binary Indexed Tree (Fenwick Tree).
+type BinaryIndexedTree2D[T any] struct {
+	origin [][]T
+	tree   [][]T
+	merge  func(T, T) T
+	// inverse is optional, it makes merge a group operation
+	inverse func(T) T
+}
+
+// NewBinaryIndexedTree2D creates and initials a new two-dimensional Binary Indexed Tree
+// from a given matrix of elements, every row must have the same length.
+// @Param merge defines how to merge two elements.
+// @Complexity O(n*m)
+func NewBinaryIndexedTree2D[T any](origin [][]T, merge func(x T, y T) T) *BinaryIndexedTree2D[T] {
+	bit := &BinaryIndexedTree2D[T]{
+		origin: origin,
+		tree:   make([][]T, len(origin)),
+		merge:  merge,
+	}
+	for i := range origin {
+		bit.tree[i] = append([]T(nil), origin[i]...)
+		// build along the columns
+		for j := 1; j <= len(origin[i]); j++ {
+			if k := j + lowBit(j); k <= len(origin[i]) {
+				bit.tree[i][k-1] = merge(bit.tree[i][k-1], bit.tree[i][j-1])
+			}
+		}
+	}
+	// build along the rows
+	for i := 1; i <= len(origin); i++ {
+		if k := i + lowBit(i); k <= len(origin) {
+			for j := range bit.tree[k-1] {
+				bit.tree[k-1][j] = merge(bit.tree[k-1][j], bit.tree[i-1][j])
+			}
+		}
+	}
+	return bit
+}
+
+// NewGroupBinaryIndexedTree2D creates and initials a new two-dimensional Binary Indexed Tree
+// whose elements form a group, which allows rectangular range queries.
+// @Param merge defines how to merge two elements.
+// @Param inverse returns the inverse element, so that merge(x, inverse(x)) is the identity.
+// @Complexity O(n*m)
+func NewGroupBinaryIndexedTree2D[T any](origin [][]T, merge func(x T, y T) T, inverse func(x T) T) *BinaryIndexedTree2D[T] {
+	bit := NewBinaryIndexedTree2D(origin, merge)
+	bit.inverse = inverse
+	return bit
+}
+
+// Accumulate calculates the accumulated value of the rectangle from (0, 0) to (r, c).
+// @Complexity O(log(n)*log(m))
+func (bit *BinaryIndexedTree2D[T]) Accumulate(r, c int) T {
+	var ret T
+	has := false
+	for i := r + 1; i > 0; i &= i - 1 {
+		for j := c + 1; j > 0; j &= j - 1 {
+			if has {
+				ret = bit.merge(ret, bit.tree[i-1][j-1])
+			} else {
+				ret, has = bit.tree[i-1][j-1], true
+			}
+		}
+	}
+	return ret
+}
+
+// Renew updates the element at the given position (r, c).
+// @Param change returns the new element from old.
+// @Complexity O(log(n)*log(m))
+func (bit *BinaryIndexedTree2D[T]) Renew(r, c int, change func(T) T) {
+	bit.origin[r][c] = change(bit.origin[r][c])
+	for i := r + 1; i <= len(bit.tree); i += lowBit(i) {
+		for j := c + 1; j <= len(bit.tree[i-1]); j += lowBit(j) {
+			bit.tree[i-1][j-1] = change(bit.tree[i-1][j-1])
+		}
+	}
+}
+
+// RangeQuery returns the merged value of the rectangle from (r1, c1) to (r2, c2).
+// It panics when the tree is not created with inverse.
+// @Complexity O(log(n)*log(m))
+func (bit *BinaryIndexedTree2D[T]) RangeQuery(r1, c1, r2, c2 int) T {
+	if bit.inverse == nil {
+		panic("RangeQuery requires inverse")
+	}
+	ret := bit.Accumulate(r2, c2)
+	if r1 > 0 {
+		ret = bit.merge(ret, bit.inverse(bit.Accumulate(r1-1, c2)))
+	}
+	if c1 > 0 {
+		ret = bit.merge(ret, bit.inverse(bit.Accumulate(r2, c1-1)))
+	}
+	if r1 > 0 && c1 > 0 {
+		ret = bit.merge(ret, bit.Accumulate(r1-1, c1-1))
+	}
+	return ret
+}
+
+// GetOrigin returns the original element at the given position (r, c).
+func (bit *BinaryIndexedTree2D[T]) GetOrigin(r, c int) T { return bit.origin[r][c] }
@@ -0,0 +1,63 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryIndexedTree2D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rows, cols := 6, 9
+	origin := make([][]int, rows)
+	for i := range origin {
+		origin[i] = make([]int, cols)
+		for j := range origin[i] {
+			origin[i][j] = r.Intn(10)
+		}
+	}
+	bit := NewGroupBinaryIndexedTree2D(origin, func(x int, y int) int { return x + y }, func(x int) int { return -x })
+	// origin is shared with the tree
+	expect := make([][]int, rows)
+	for i := range expect {
+		expect[i] = append([]int(nil), origin[i]...)
+	}
+	sum := func(r1, c1, r2, c2 int) int {
+		s := 0
+		for i := r1; i <= r2; i++ {
+			for j := c1; j <= c2; j++ {
+				s += expect[i][j]
+			}
+		}
+		return s
+	}
+	for round := range 20 {
+		for r1 := range rows {
+			for c1 := range cols {
+				require.Equal(t, sum(0, 0, r1, c1), bit.Accumulate(r1, c1), round)
+				for r2 := r1; r2 < rows; r2++ {
+					for c2 := c1; c2 < cols; c2++ {
+						require.Equal(t, sum(r1, c1, r2, c2), bit.RangeQuery(r1, c1, r2, c2), round)
+					}
+				}
+			}
+		}
+		i, j, d := r.Intn(rows), r.Intn(cols), r.Intn(11)-5
+		bit.Renew(i, j, func(x int) int { return x + d })
+		expect[i][j] += d
+		require.Equal(t, expect[i][j], bit.GetOrigin(i, j))
+	}
+}
+
+func TestBinaryIndexedTree2D_Max(t *testing.T) {
+	origin := [][]int{{1, 5, 2}, {4, 3, 0}, {2, 8, 1}}
+	bit := NewBinaryIndexedTree2D(origin, func(x int, y int) int { return max(x, y) })
+	require.Equal(t, 1, bit.Accumulate(0, 0))
+	require.Equal(t, 5, bit.Accumulate(1, 1))
+	require.Equal(t, 4, bit.Accumulate(2, 0))
+	require.Equal(t, 8, bit.Accumulate(2, 2))
+	bit.Renew(0, 0, func(x int) int { return max(x, 9) })
+	require.Equal(t, 9, bit.Accumulate(1, 1))
+	require.Panics(t, func() { bit.RangeQuery(0, 0, 1, 1) })
+}
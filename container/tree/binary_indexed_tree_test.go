@@ -31,3 +31,71 @@ func TestBinaryIndexedTree_Range(t *testing.T) {
 		}
 	}
 }
+
+func TestBinaryIndexedTree_RangeQuery(t *testing.T) {
+	origin := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	sum := NewGroupBinaryIndexedTree(append([]int(nil), origin...),
+		func(x int, y int) int { return x + y }, func(x int) int { return -x })
+	mx := NewBinaryIndexedTree(append([]int(nil), origin...), func(x int, y int) int { return max(x, y) })
+	check := func() {
+		for l := range origin {
+			s, m := 0, 0
+			for r := l; r < len(origin); r++ {
+				s, m = s+origin[r], max(m, origin[r])
+				require.Equal(t, s, sum.RangeQuery(l, r), l, r)
+				require.Equal(t, m, mx.RangeQuery(l, r), l, r)
+			}
+		}
+	}
+	check()
+	for i, v := range []int{7, 0, 2, 8, 1, 1, 10, 3} {
+		origin[i] = v
+		sum.Set(i, v)
+		mx.Set(i, v)
+		require.Equal(t, v, sum.GetOrigin(i))
+		require.Equal(t, v, mx.GetOrigin(i))
+		check()
+	}
+}
+
+func TestBinaryIndexedTree_LowerBound(t *testing.T) {
+	counts := []int{2, 0, 3, 1, 0, 0, 4, 1, 2}
+	bit := NewBinaryIndexedTree(append([]int(nil), counts...), func(x int, y int) int { return x + y })
+	check := func() {
+		total := 0
+		for _, c := range counts {
+			total += c
+		}
+		for target := 0; target <= total+1; target++ {
+			expect, sum := len(counts), 0
+			for i, c := range counts {
+				if sum += c; sum >= target {
+					expect = i
+					break
+				}
+			}
+			require.Equal(t, expect, bit.LowerBound(func(prefix int) bool { return prefix >= target }), target)
+		}
+	}
+	check()
+	for _, c := range []int{0, 5, 1, 3, 0, 0, 2} {
+		counts = append(counts, c)
+		bit.Append(c)
+		for i := range counts {
+			require.Equal(t, counts[i], bit.GetOrigin(i))
+		}
+		check()
+	}
+	// empty
+	bit = NewBinaryIndexedTree([]int{}, func(x int, y int) int { return x + y })
+	require.Equal(t, 0, bit.LowerBound(func(int) bool { return true }))
+	bit.Append(3)
+	require.Equal(t, 3, bit.Accumulate(0))
+	// the spare capacity of the caller's slice is untouched
+	buf := []int{1, 2, 3, 4}
+	bit = NewBinaryIndexedTree(buf[:2], func(x int, y int) int { return x + y })
+	bit.Append(5)
+	bit.Append(6)
+	require.Equal(t, []int{1, 2, 3, 4}, buf)
+	require.Equal(t, 14, bit.Accumulate(3))
+}
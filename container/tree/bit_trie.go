@@ -0,0 +1,208 @@
+package tree
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+type _BitTrieNode[V any] struct {
+	children [2]*_BitTrieNode[V]
+	// size is the number of keys in the subtree
+	size int
+	// val is the value of the prefix ending at the node, valid if ok
+	val V
+	ok  bool
+}
+
+func (n *_BitTrieNode[V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// BitTrie is a fixed-width binary trie over the bits of unsigned keys from the most significant one.
+// It keeps a set of keys for xor and order queries, and maps prefixes to values for longest prefix match.
+// The width is at most 64 bits, so it covers IPv4 CIDRs with uint32 keys, while IPv6 only fits
+// with the upper 64 bits of addresses as uint64 keys, that is prefixes up to /64.
+type BitTrie[K constraints.Unsigned, V any] struct {
+	root  *_BitTrieNode[V]
+	width int
+}
+
+// NewBitTrie creates a new instance of the BitTrie, the width is the bit size of K.
+func NewBitTrie[K constraints.Unsigned, V any]() *BitTrie[K, V] {
+	width := 0
+	for m := ^K(0); m > 0; m >>= 1 {
+		width++
+	}
+	return &BitTrie[K, V]{
+		root:  new(_BitTrieNode[V]),
+		width: width,
+	}
+}
+
+// bit returns the i-th bit of the key counted from the most significant one.
+func (t *BitTrie[K, V]) bit(key K, i int) int { return int(key>>(t.width-1-i)) & 1 }
+
+// Size returns the number of keys.
+// @Complexity O(1)
+func (t *BitTrie[K, V]) Size() int { return t.root.size }
+
+// Contains checks if the key exists.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) Contains(key K) bool {
+	n := t.root
+	for i := 0; i < t.width && n != nil; i++ {
+		n = n.children[t.bit(key, i)]
+	}
+	return n.getSize() > 0
+}
+
+// Insert adds the key, returns false if it exists already.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) Insert(key K) bool {
+	if t.Contains(key) {
+		return false
+	}
+	n := t.root
+	n.size++
+	for i := range t.width {
+		b := t.bit(key, i)
+		if n.children[b] == nil {
+			n.children[b] = new(_BitTrieNode[V])
+		}
+		n = n.children[b]
+		n.size++
+	}
+	return true
+}
+
+// Remove deletes the key, returns false if it does not exist.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) Remove(key K) bool {
+	if !t.Contains(key) {
+		return false
+	}
+	path := make([]*_BitTrieNode[V], 0, t.width)
+	n := t.root
+	n.size--
+	for i := range t.width {
+		path = append(path, n)
+		n = n.children[t.bit(key, i)]
+		n.size--
+	}
+	t.cut(key, path, n)
+	return true
+}
+
+// cut removes the empty nodes from n up along the path of the key, path[i] is the node at depth i.
+func (t *BitTrie[K, V]) cut(key K, path []*_BitTrieNode[V], n *_BitTrieNode[V]) {
+	for i := len(path) - 1; i >= 0 && t.removable(n); i-- {
+		path[i].children[t.bit(key, i)] = nil
+		n = path[i]
+	}
+}
+
+// removable checks if the subtree holds neither keys nor prefix values.
+func (t *BitTrie[K, V]) removable(n *_BitTrieNode[V]) bool {
+	return n.size == 0 && !n.ok && n.children[0] == nil && n.children[1] == nil
+}
+
+// MaxXor returns the key k maximizing x^k, ok is false if there is no key.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) MaxXor(x K) (key K, ok bool) { return t.xor(x, 1) }
+
+// MinXor returns the key k minimizing x^k, ok is false if there is no key.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) MinXor(x K) (key K, ok bool) { return t.xor(x, 0) }
+
+// xor walks greedily, preferring the child whose bit xor the bit of x equals prefer.
+func (t *BitTrie[K, V]) xor(x K, prefer int) (key K, ok bool) {
+	if t.Size() == 0 {
+		return key, false
+	}
+	n := t.root
+	for i := range t.width {
+		b := t.bit(x, i) ^ prefer
+		if n.children[b].getSize() == 0 {
+			b ^= 1
+		}
+		key = key<<1 | K(b)
+		n = n.children[b]
+	}
+	return key, true
+}
+
+// CountLess returns the number of keys less than v.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) CountLess(v K) int {
+	cnt := 0
+	n := t.root
+	for i := 0; i < t.width && n != nil; i++ {
+		b := t.bit(v, i)
+		if b == 1 {
+			cnt += n.children[0].getSize()
+		}
+		n = n.children[b]
+	}
+	return cnt
+}
+
+// SetPrefix maps the first prefixLen bits of the prefix to the value like a CIDR block,
+// for example 10.0.0.0/8 is SetPrefix(0x0A000000, 8, val) with uint32 keys.
+// It panics when prefixLen is out of [0, width].
+// @Complexity O(prefixLen)
+func (t *BitTrie[K, V]) SetPrefix(prefix K, prefixLen int, val V) {
+	t.checkPrefixLen(prefixLen)
+	n := t.root
+	for i := range prefixLen {
+		b := t.bit(prefix, i)
+		if n.children[b] == nil {
+			n.children[b] = new(_BitTrieNode[V])
+		}
+		n = n.children[b]
+	}
+	n.val, n.ok = val, true
+}
+
+// RemovePrefix deletes the value of the prefix, returns false if it does not exist.
+// It panics when prefixLen is out of [0, width].
+// @Complexity O(prefixLen)
+func (t *BitTrie[K, V]) RemovePrefix(prefix K, prefixLen int) bool {
+	t.checkPrefixLen(prefixLen)
+	path := make([]*_BitTrieNode[V], 0, prefixLen+1)
+	n := t.root
+	for i := 0; i < prefixLen && n != nil; i++ {
+		path = append(path, n)
+		n = n.children[t.bit(prefix, i)]
+	}
+	if n == nil || !n.ok {
+		return false
+	}
+	var zero V
+	n.val, n.ok = zero, false
+	t.cut(prefix, path, n)
+	return true
+}
+
+// LongestPrefixMatch returns the value of the longest prefix matching the key and the prefix length.
+// @Complexity O(width)
+func (t *BitTrie[K, V]) LongestPrefixMatch(key K) (prefixLen int, val V, ok bool) {
+	n := t.root
+	for i := 0; n != nil; i++ {
+		if n.ok {
+			prefixLen, val, ok = i, n.val, true
+		}
+		if i == t.width {
+			break
+		}
+		n = n.children[t.bit(key, i)]
+	}
+	return prefixLen, val, ok
+}
+
+func (t *BitTrie[K, V]) checkPrefixLen(prefixLen int) {
+	if prefixLen < 0 || prefixLen > t.width {
+		panic("prefix length out of range")
+	}
+}
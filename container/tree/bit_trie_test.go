@@ -0,0 +1,112 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitTrie(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bt := NewBitTrie[uint8, struct{}]()
+	require.Equal(t, 8, bt.width)
+	_, ok := bt.MaxXor(0)
+	require.False(t, ok)
+	_, ok = bt.MinXor(0)
+	require.False(t, ok)
+	// prefix values do not affect keys
+	bt.SetPrefix(0b1010_0000, 4, struct{}{})
+
+	keys := make(map[uint8]bool)
+	for i := range 400 {
+		key := uint8(r.Intn(256))
+		if i%3 == 2 {
+			require.Equal(t, keys[key], bt.Remove(key))
+			delete(keys, key)
+		} else {
+			require.Equal(t, !keys[key], bt.Insert(key))
+			keys[key] = true
+		}
+		require.Equal(t, len(keys), bt.Size())
+	}
+	for x := range 256 {
+		require.Equal(t, keys[uint8(x)], bt.Contains(uint8(x)), x)
+		less, maxXor, minXor := 0, -1, 256
+		for k := range keys {
+			if int(k) < x {
+				less++
+			}
+			maxXor, minXor = max(maxXor, int(k)^x), min(minXor, int(k)^x)
+		}
+		require.Equal(t, less, bt.CountLess(uint8(x)), x)
+		k, ok := bt.MaxXor(uint8(x))
+		require.True(t, ok)
+		require.Equal(t, maxXor, int(k)^x, x)
+		k, ok = bt.MinXor(uint8(x))
+		require.True(t, ok)
+		require.Equal(t, minXor, int(k)^x, x)
+	}
+	for k := range keys {
+		require.True(t, bt.Remove(k))
+	}
+	require.Equal(t, 0, bt.Size())
+	// the prefix value is kept
+	n, _, ok := bt.LongestPrefixMatch(0b1010_1111)
+	require.True(t, ok)
+	require.Equal(t, 4, n)
+}
+
+func TestBitTrie_LongestPrefixMatch(t *testing.T) {
+	ip := func(a, b, c, d uint32) uint32 { return a<<24 | b<<16 | c<<8 | d }
+	bt := NewBitTrie[uint32, string]()
+	_, _, ok := bt.LongestPrefixMatch(ip(10, 1, 2, 3))
+	require.False(t, ok)
+
+	bt.SetPrefix(ip(10, 0, 0, 0), 8, "10/8")
+	bt.SetPrefix(ip(10, 1, 0, 0), 16, "10.1/16")
+	bt.SetPrefix(ip(10, 1, 2, 0), 24, "10.1.2/24")
+	bt.SetPrefix(ip(10, 1, 2, 3), 32, "host")
+	bt.SetPrefix(0, 0, "default")
+	bt.Insert(ip(10, 1, 2, 3))
+
+	for _, c := range []struct {
+		key uint32
+		n   int
+		val string
+	}{
+		{ip(10, 1, 2, 3), 32, "host"},
+		{ip(10, 1, 2, 4), 24, "10.1.2/24"},
+		{ip(10, 1, 3, 4), 16, "10.1/16"},
+		{ip(10, 2, 3, 4), 8, "10/8"},
+		{ip(192, 168, 0, 1), 0, "default"},
+	} {
+		n, val, ok := bt.LongestPrefixMatch(c.key)
+		require.True(t, ok)
+		require.Equal(t, c.n, n)
+		require.Equal(t, c.val, val)
+	}
+
+	require.True(t, bt.RemovePrefix(ip(10, 1, 2, 0), 24))
+	require.False(t, bt.RemovePrefix(ip(10, 1, 2, 0), 24))
+	require.False(t, bt.RemovePrefix(ip(172, 16, 0, 0), 12))
+	n, val, _ := bt.LongestPrefixMatch(ip(10, 1, 2, 4))
+	require.Equal(t, 16, n)
+	require.Equal(t, "10.1/16", val)
+	// the key below the removed prefix is kept
+	require.True(t, bt.Contains(ip(10, 1, 2, 3)))
+	require.True(t, bt.RemovePrefix(ip(10, 1, 2, 3), 32))
+	require.True(t, bt.Contains(ip(10, 1, 2, 3)))
+	require.True(t, bt.Remove(ip(10, 1, 2, 3)))
+	require.True(t, bt.RemovePrefix(ip(10, 1, 0, 0), 16))
+	// only the path of 10/8 is left
+	require.Equal(t, 8, countBitTrieNodes(bt.root)-1)
+	require.Panics(t, func() { bt.SetPrefix(0, 33, "") })
+}
+
+func countBitTrieNodes[V any](n *_BitTrieNode[V]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + countBitTrieNodes(n.children[0]) + countBitTrieNodes(n.children[1])
+}
@@ -0,0 +1,122 @@
+package tree
+
+import (
+	"iter"
+	"sync"
+)
+
+// ConcurrentAvlTree is an AvlTree safe for concurrent use,
+// lookups hold the read lock and mutations hold the write lock.
+type ConcurrentAvlTree[K, V any] struct {
+	mu  sync.RWMutex
+	avl *AvlTree[K, V]
+}
+
+// NewConcurrentAvlTree creates an empty concurrent AVL tree with a given comparison function
+func NewConcurrentAvlTree[K, V any](cmp func(a, b K) int) *ConcurrentAvlTree[K, V] {
+	return &ConcurrentAvlTree[K, V]{
+		avl: NewAvlTree[K, V](cmp),
+	}
+}
+
+// Size returns the total number of elements.
+// Complexity: O(1)
+func (c *ConcurrentAvlTree[K, V]) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.avl.Size()
+}
+
+// Get searches for a value and returns (value, true) if found
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.avl.Get(key)
+}
+
+// Contains returns existence of a value
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.avl.Contains(key)
+}
+
+// Rank finds the k-th smallest element (1-based index)
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) Rank(k int) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.avl.Rank(k)
+}
+
+// Set inserts a value or updates if exists through compare function
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) Set(key K, val V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.avl.Set(key, val)
+}
+
+// Remove deletes a value from the tree if exists
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.avl.Remove(key)
+}
+
+// ComputeIfAbsent returns the existing value of the key with loaded true,
+// otherwise stores and returns the value produced by compute.
+// The whole operation is atomic, compute runs under the write lock and must not access the tree.
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) ComputeIfAbsent(key K, compute func() V) (val V, loaded bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if val, loaded = c.avl.Get(key); loaded {
+		return val, true
+	}
+	val = compute()
+	c.avl.Set(key, val)
+	return val, false
+}
+
+// Compute atomically replaces the value of the key with the result of update,
+// old and exists describe the current value. The key is removed when keep is false.
+// update runs under the write lock and must not access the tree.
+// Complexity: O(log(n))
+func (c *ConcurrentAvlTree[K, V]) Compute(key K, update func(old V, exists bool) (val V, keep bool)) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, exists := c.avl.Get(key)
+	val, keep := update(old, exists)
+	if !keep {
+		if exists {
+			c.avl.Remove(key)
+		}
+		return val, false
+	}
+	c.avl.Set(key, val)
+	return val, true
+}
+
+// Iter provides an in-order traversal iterator over a snapshot taken under the read lock,
+// the lock is released before yielding, so the loop body may modify the tree.
+// Complexity: O(n) for taking the snapshot
+func (c *ConcurrentAvlTree[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.mu.RLock()
+		keys, vals := make([]K, 0, c.avl.Size()), make([]V, 0, c.avl.Size())
+		for k, v := range c.avl.Iter() {
+			keys = append(keys, k)
+			vals = append(vals, v)
+		}
+		c.mu.RUnlock()
+		for i := range keys {
+			if !yield(keys[i], vals[i]) {
+				return
+			}
+		}
+	}
+}
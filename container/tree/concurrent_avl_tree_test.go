@@ -0,0 +1,104 @@
+package tree
+
+import (
+	"cmp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentAvlTree(t *testing.T) {
+	c := NewConcurrentAvlTree[int, int](cmp.Compare)
+	c.Set(2, 2)
+	c.Set(1, 1)
+	c.Set(3, 3)
+	require.Equal(t, 3, c.Size())
+	require.True(t, c.Contains(2))
+	v, ok := c.Get(3)
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	v, ok = c.Rank(1)
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	c.Remove(2)
+	require.False(t, c.Contains(2))
+
+	// ComputeIfAbsent
+	v, loaded := c.ComputeIfAbsent(1, func() int { return 10 })
+	require.True(t, loaded)
+	require.Equal(t, 1, v)
+	v, loaded = c.ComputeIfAbsent(2, func() int { return 20 })
+	require.False(t, loaded)
+	require.Equal(t, 20, v)
+
+	// Compute
+	v, ok = c.Compute(2, func(old int, exists bool) (int, bool) {
+		require.True(t, exists)
+		return old + 1, true
+	})
+	require.True(t, ok)
+	require.Equal(t, 21, v)
+	_, ok = c.Compute(2, func(int, bool) (int, bool) { return 0, false })
+	require.False(t, ok)
+	require.False(t, c.Contains(2))
+	_, ok = c.Compute(4, func(_ int, exists bool) (int, bool) {
+		require.False(t, exists)
+		return 0, false
+	})
+	require.False(t, ok)
+	require.Equal(t, 2, c.Size())
+
+	// modify while iterating does not dead lock
+	keys := make([]int, 0)
+	for k := range c.Iter() {
+		keys = append(keys, k)
+		c.Set(k+10, k)
+	}
+	require.Equal(t, []int{1, 3}, keys)
+	require.Equal(t, 4, c.Size())
+}
+
+func TestConcurrentAvlTree_Race(t *testing.T) {
+	c := NewConcurrentAvlTree[int, int](cmp.Compare)
+	const workers, loops = 8, 200
+	var wg sync.WaitGroup
+	// require must not be called off the test goroutine, so failures are checked after Wait
+	var unordered atomic.Bool
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range loops {
+				key := i % 50
+				switch (w + i) % 5 {
+				case 0:
+					c.Set(key, i)
+				case 1:
+					c.Remove(key)
+				case 2:
+					c.Compute(-1, func(old int, _ bool) (int, bool) { return old + 1, true })
+				case 3:
+					c.ComputeIfAbsent(key, func() int { return i })
+				case 4:
+					last := -2
+					for k := range c.Iter() {
+						if k <= last {
+							unordered.Store(true)
+						}
+						last = k
+					}
+				}
+				c.Get(key)
+				c.Size()
+			}
+		}()
+	}
+	wg.Wait()
+	require.False(t, unordered.Load(), "snapshot iteration is not ordered")
+	// every Compute on -1 increments exactly once
+	v, ok := c.Get(-1)
+	require.True(t, ok)
+	require.Equal(t, workers*loops/5, v)
+}
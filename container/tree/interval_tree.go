@@ -0,0 +1,131 @@
+package tree
+
+import (
+	"iter"
+)
+
+// Interval represents the half-open interval [Lo, Hi)
+type Interval[K any] struct {
+	Lo, Hi K
+}
+
+// _MaxEnd is the max Hi of a subtree, ok is false for an empty subtree
+type _MaxEnd[K any] struct {
+	end K
+	ok  bool
+}
+
+// IntervalTree stores half-open intervals with values and answers overlap queries,
+// it is an AugmentedAvlTree ordered by (Lo, Hi) keeping the max Hi of every subtree.
+type IntervalTree[K, V any] struct {
+	tree *AugmentedAvlTree[Interval[K], V, _MaxEnd[K]]
+	cmp  func(a, b K) int
+}
+
+// NewIntervalTree creates an empty IntervalTree with a given comparison function of the endpoints
+func NewIntervalTree[K, V any](cmp func(a, b K) int) *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{
+		tree: NewAugmentedAvlTree(
+			func(a, b Interval[K]) int {
+				if c := cmp(a.Lo, b.Lo); c != 0 {
+					return c
+				}
+				return cmp(a.Hi, b.Hi)
+			},
+			_MaxEnd[K]{},
+			func(x, y _MaxEnd[K]) _MaxEnd[K] {
+				if !x.ok || y.ok && cmp(x.end, y.end) < 0 {
+					return y
+				}
+				return x
+			},
+			func(key Interval[K], _ V) _MaxEnd[K] { return _MaxEnd[K]{end: key.Hi, ok: true} },
+		),
+		cmp: cmp,
+	}
+}
+
+// Size returns the total number of intervals.
+// Complexity: O(1)
+func (it *IntervalTree[K, V]) Size() int { return it.tree.Size() }
+
+// Get returns the value of the interval [lo, hi) if exists
+// Complexity: O(log(n))
+func (it *IntervalTree[K, V]) Get(lo, hi K) (V, bool) { return it.tree.Get(Interval[K]{lo, hi}) }
+
+// Insert adds the interval [lo, hi) with a value, or updates the value if the interval exists.
+// It panics when the interval is empty.
+// Complexity: O(log(n))
+func (it *IntervalTree[K, V]) Insert(lo, hi K, val V) {
+	if it.cmp(lo, hi) >= 0 {
+		panic("interval is empty")
+	}
+	it.tree.Set(Interval[K]{lo, hi}, val)
+}
+
+// Remove deletes the interval [lo, hi) if exists
+// Complexity: O(log(n))
+func (it *IntervalTree[K, V]) Remove(lo, hi K) { it.tree.Remove(Interval[K]{lo, hi}) }
+
+// Overlapping provides an iterator over the intervals overlapping [lo, hi) ordered by (Lo, Hi),
+// an empty [lo, hi) overlaps nothing
+// Complexity: O((m+1)*log(n)), m is the number of overlapping intervals
+func (it *IntervalTree[K, V]) Overlapping(lo, hi K) iter.Seq2[Interval[K], V] {
+	startBefore := func(start K) bool { return it.cmp(start, hi) < 0 }
+	return func(yield func(Interval[K], V) bool) {
+		if it.cmp(lo, hi) >= 0 {
+			return
+		}
+		it.search(it.tree.avl.root, lo, startBefore, yield)
+	}
+}
+
+// Stabbing provides an iterator over the intervals containing the point ordered by (Lo, Hi)
+// Complexity: O((m+1)*log(n)), m is the number of containing intervals
+func (it *IntervalTree[K, V]) Stabbing(point K) iter.Seq2[Interval[K], V] {
+	startBefore := func(start K) bool { return it.cmp(start, point) <= 0 }
+	return func(yield func(Interval[K], V) bool) {
+		it.search(it.tree.avl.root, point, startBefore, yield)
+	}
+}
+
+// AnyOverlap returns one interval overlapping [lo, hi) if exists, an empty [lo, hi) overlaps nothing
+// Complexity: O(log(n))
+func (it *IntervalTree[K, V]) AnyOverlap(lo, hi K) (interval Interval[K], val V, ok bool) {
+	if it.cmp(lo, hi) >= 0 {
+		return interval, val, false
+	}
+	for n := it.tree.avl.root; n != nil; {
+		if it.cmp(n.key.Lo, hi) < 0 && it.cmp(lo, n.key.Hi) < 0 {
+			return n.key, n.val.val, true
+		}
+		// the left subtree can overlap only if some interval in it ends after lo,
+		// otherwise only the right subtree may overlap
+		if l := n.left; l != nil && it.cmp(l.val.agg.end, lo) > 0 {
+			n = l
+		} else {
+			n = n.right
+		}
+	}
+	return interval, val, false
+}
+
+// search yields the intervals in the subtree which end after lo and whose start satisfies startBefore
+func (it *IntervalTree[K, V]) search(n *_AvlTreeNode[Interval[K], _AugmentedEntry[V, _MaxEnd[K]]], lo K,
+	startBefore func(K) bool, yield func(Interval[K], V) bool) bool {
+	// no interval in the subtree ends after lo
+	if n == nil || it.cmp(n.val.agg.end, lo) <= 0 {
+		return true
+	}
+	if !it.search(n.left, lo, startBefore, yield) {
+		return false
+	}
+	// the starts of n and its right subtree are all too late
+	if !startBefore(n.key.Lo) {
+		return true
+	}
+	if it.cmp(lo, n.key.Hi) < 0 && !yield(n.key, n.val.val) {
+		return false
+	}
+	return it.search(n.right, lo, startBefore, yield)
+}
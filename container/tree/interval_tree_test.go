@@ -0,0 +1,104 @@
+package tree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalTree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	it := NewIntervalTree[int, int](cmp.Compare)
+	expect := make(map[Interval[int]]int)
+	for i := range 300 {
+		lo := r.Intn(50)
+		hi := lo + 1 + r.Intn(10)
+		if i%4 == 3 {
+			it.Remove(lo, hi)
+			delete(expect, Interval[int]{lo, hi})
+		} else {
+			it.Insert(lo, hi, i)
+			expect[Interval[int]{lo, hi}] = i
+		}
+	}
+	require.Equal(t, len(expect), it.Size())
+	for interval, v := range expect {
+		val, ok := it.Get(interval.Lo, interval.Hi)
+		require.True(t, ok)
+		require.Equal(t, v, val)
+	}
+	sortIntervals := func(s []Interval[int]) {
+		slices.SortFunc(s, func(a, b Interval[int]) int {
+			return cmp.Or(cmp.Compare(a.Lo, b.Lo), cmp.Compare(a.Hi, b.Hi))
+		})
+	}
+	for lo := -1; lo <= 62; lo++ {
+		// empty and reversed queries are included
+		for hi := lo - 1; hi <= 62; hi++ {
+			want := make([]Interval[int], 0)
+			for interval := range expect {
+				if lo < hi && interval.Lo < hi && lo < interval.Hi {
+					want = append(want, interval)
+				}
+			}
+			sortIntervals(want)
+			got := make([]Interval[int], 0)
+			for interval, v := range it.Overlapping(lo, hi) {
+				require.Equal(t, expect[interval], v)
+				got = append(got, interval)
+			}
+			require.Equal(t, want, got, lo, hi)
+
+			interval, v, ok := it.AnyOverlap(lo, hi)
+			require.Equal(t, len(want) > 0, ok, lo, hi)
+			if ok {
+				require.Contains(t, want, interval)
+				require.Equal(t, expect[interval], v)
+			}
+		}
+
+		want := make([]Interval[int], 0)
+		for interval := range expect {
+			if interval.Lo <= lo && lo < interval.Hi {
+				want = append(want, interval)
+			}
+		}
+		sortIntervals(want)
+		got := make([]Interval[int], 0)
+		for interval := range it.Stabbing(lo) {
+			got = append(got, interval)
+		}
+		require.Equal(t, want, got, lo)
+	}
+	require.Panics(t, func() { it.Insert(1, 1, 0) })
+
+	it = NewIntervalTree[int, int](cmp.Compare)
+	it.Insert(0, 10, 1)
+	for range it.Overlapping(5, 5) {
+		require.Fail(t, "empty query overlaps nothing")
+	}
+	_, _, ok := it.AnyOverlap(5, 5)
+	require.False(t, ok)
+	_, _, ok = it.AnyOverlap(5, 6)
+	require.True(t, ok)
+}
+
+func TestIntervalTree_Break(t *testing.T) {
+	it := NewIntervalTree[int, string](cmp.Compare)
+	it.Insert(0, 10, "a")
+	it.Insert(2, 4, "b")
+	it.Insert(5, 8, "c")
+	got := make([]string, 0)
+	for _, v := range it.Overlapping(3, 6) {
+		got = append(got, v)
+		if v == "b" {
+			break
+		}
+	}
+	require.Equal(t, []string{"a", "b"}, got)
+	_, _, ok := it.AnyOverlap(10, 20)
+	require.False(t, ok)
+}
@@ -0,0 +1,134 @@
+package tree
+
+import (
+	"iter"
+)
+
+// clone returns a shallow copy of the node, children are shared
+func (n *_AvlTreeNode[K, V]) clone() *_AvlTreeNode[K, V] {
+	c := *n
+	return &c
+}
+
+// persistentReBalance copies the nodes which will be rotated before re-balancing,
+// n itself must be a private copy already
+func (n *_AvlTreeNode[K, V]) persistentReBalance() *_AvlTreeNode[K, V] {
+	switch n.getFactor() {
+	case 2:
+		n.left = n.left.clone()
+		if n.left.getFactor() == -1 {
+			n.left.right = n.left.right.clone()
+		}
+	case -2:
+		n.right = n.right.clone()
+		if n.right.getFactor() == 1 {
+			n.right.left = n.right.left.clone()
+		}
+	}
+	return n.reBalance()
+}
+
+// PersistentAvlTree represents an immutable AVL tree,
+// every modification returns a new version sharing unchanged nodes with the old one
+type PersistentAvlTree[K, V any] struct {
+	root *_AvlTreeNode[K, V]
+	cmp  func(a, b K) int
+}
+
+// NewPersistentAvlTree creates an empty persistent AVL tree with a given comparison function
+func NewPersistentAvlTree[K, V any](cmp func(a, b K) int) *PersistentAvlTree[K, V] {
+	return &PersistentAvlTree[K, V]{
+		root: nil,
+		cmp:  cmp,
+	}
+}
+
+// view returns a read-only AvlTree over the same nodes
+func (p *PersistentAvlTree[K, V]) view() *AvlTree[K, V] {
+	return &AvlTree[K, V]{root: p.root, cmp: p.cmp}
+}
+
+// Size returns the total number of elements.
+// Complexity: O(1)
+func (p *PersistentAvlTree[K, V]) Size() int { return p.root.getSize() }
+
+// Get searches for a value and returns (value, true) if found
+// Complexity: O(log(n))
+func (p *PersistentAvlTree[K, V]) Get(key K) (V, bool) { return p.view().Get(key) }
+
+// Contains returns existence of a value
+// Complexity: O(log(n))
+func (p *PersistentAvlTree[K, V]) Contains(key K) bool { return p.view().Contains(key) }
+
+// Rank finds the k-th smallest element (1-based index)
+// Complexity: O(log(n))
+func (p *PersistentAvlTree[K, V]) Rank(k int) (V, bool) { return p.view().Rank(k) }
+
+// Set returns a new version with the value inserted or updated, the receiver is unchanged
+// Complexity: O(log(n))
+func (p *PersistentAvlTree[K, V]) Set(key K, val V) *PersistentAvlTree[K, V] {
+	return &PersistentAvlTree[K, V]{root: p.set(p.root, key, val), cmp: p.cmp}
+}
+
+func (p *PersistentAvlTree[K, V]) set(n *_AvlTreeNode[K, V], key K, val V) *_AvlTreeNode[K, V] {
+	if n == nil {
+		return newNode(key, val)
+	}
+	n = n.clone()
+	c := p.cmp(key, n.key)
+	// renew value when equal
+	if c == 0 {
+		n.val = val
+		return n
+	}
+	if c < 0 {
+		n.left = p.set(n.left, key, val)
+	} else {
+		n.right = p.set(n.right, key, val)
+	}
+	return n.persistentReBalance()
+}
+
+// Remove returns a new version without the key, the receiver is unchanged.
+// It returns the receiver itself when the key does not exist.
+// Complexity: O(log(n))
+func (p *PersistentAvlTree[K, V]) Remove(key K) *PersistentAvlTree[K, V] {
+	if !p.Contains(key) {
+		return p
+	}
+	return &PersistentAvlTree[K, V]{root: p.remove(p.root, key), cmp: p.cmp}
+}
+
+func (p *PersistentAvlTree[K, V]) remove(n *_AvlTreeNode[K, V], key K) *_AvlTreeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := p.cmp(key, n.key)
+	if c == 0 {
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+	}
+	n = n.clone()
+	if c < 0 {
+		n.left = p.remove(n.left, key)
+	} else if c > 0 {
+		n.right = p.remove(n.right, key)
+	} else {
+		// find the successor of n, which is the leftmost node in n's right subtree
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		// the successor is shared, so copy its content instead of swapping
+		n.key, n.val = successor.key, successor.val
+		n.right = p.remove(n.right, successor.key)
+	}
+	return n.persistentReBalance()
+}
+
+// Iter provides an in-order traversal iterator
+func (p *PersistentAvlTree[K, V]) Iter() iter.Seq2[K, V] { return p.view().Iter() }
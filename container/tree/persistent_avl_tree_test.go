@@ -0,0 +1,72 @@
+package tree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func checkPersistentAvlTree(t *testing.T, p *PersistentAvlTree[int, int], expect []int, msg ...any) {
+	checkAvlBalance(t, p.root, msg...)
+	require.Equal(t, len(expect), p.Size(), msg...)
+	for i := range expect {
+		require.True(t, p.Contains(expect[i]), msg...)
+		e, ok := p.Get(expect[i])
+		require.True(t, ok, msg...)
+		require.Equal(t, expect[i], e, msg...)
+		e, ok = p.Rank(i + 1)
+		require.True(t, ok, msg...)
+		require.Equal(t, expect[i], e, msg...)
+	}
+	keys := make([]int, 0, len(expect))
+	for k := range p.Iter() {
+		keys = append(keys, k)
+	}
+	require.Equal(t, expect, keys, msg...)
+}
+
+func TestPersistentAvlTree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	versions := []*PersistentAvlTree[int, int]{NewPersistentAvlTree[int, int](cmp.Compare)}
+	expects := [][]int{{}}
+	for i := range 300 {
+		last, expect := versions[len(versions)-1], slices.Clone(expects[len(expects)-1])
+		key := r.Intn(50)
+		var next *PersistentAvlTree[int, int]
+		if i%3 == 2 {
+			next = last.Remove(key)
+			if idx, ok := slices.BinarySearch(expect, key); ok {
+				expect = slices.Delete(expect, idx, idx+1)
+			} else {
+				require.Same(t, last, next)
+			}
+		} else {
+			next = last.Set(key, key)
+			if idx, ok := slices.BinarySearch(expect, key); !ok {
+				expect = slices.Insert(expect, idx, key)
+			}
+		}
+		versions = append(versions, next)
+		expects = append(expects, expect)
+	}
+	// every version keeps its own content
+	for i := range versions {
+		checkPersistentAvlTree(t, versions[i], expects[i], "version", i)
+	}
+}
+
+func TestPersistentAvlTree_Update(t *testing.T) {
+	v1 := NewPersistentAvlTree[string, int](cmp.Compare).Set("a", 1).Set("b", 2)
+	v2 := v1.Set("a", 10)
+	val, _ := v1.Get("a")
+	require.Equal(t, 1, val)
+	val, _ = v2.Get("a")
+	require.Equal(t, 10, val)
+	v3 := v2.Remove("a")
+	require.False(t, v3.Contains("a"))
+	require.True(t, v2.Contains("a"))
+	require.Equal(t, 1, v3.Size())
+}
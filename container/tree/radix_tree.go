@@ -0,0 +1,203 @@
+package tree
+
+import (
+	"iter"
+	"slices"
+)
+
+type _RadixTreeNode[T comparable] struct {
+	// label is the elements of the edge from the parent to the node
+	label []T
+	cnt   int
+	// children are distinguished by the first element of their labels
+	children []*_RadixTreeNode[T]
+}
+
+// child returns the index of the child whose label starts with e, -1 if not exists.
+func (n *_RadixTreeNode[T]) child(e T) int {
+	for i, c := range n.children {
+		if c.label[0] == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix[T comparable](a, b []T) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// RadixTree is a compressed prefix tree merging single-child chains into edge labels,
+// it has the same behaviors as Trie with less memory.
+type RadixTree[T comparable] struct {
+	root *_RadixTreeNode[T]
+}
+
+// NewRadixTree creates a new instance of the RadixTree.
+func NewRadixTree[T comparable]() *RadixTree[T] {
+	return &RadixTree[T]{
+		root: new(_RadixTreeNode[T]),
+	}
+}
+
+// Add inserts a sequence of elements into the RadixTree with a given count.
+func (t *RadixTree[T]) Add(cnt int, seq ...T) {
+	n := t.root
+	for len(seq) > 0 {
+		i := n.child(seq[0])
+		if i < 0 {
+			n.children = append(n.children, &_RadixTreeNode[T]{label: slices.Clone(seq), cnt: cnt})
+			return
+		}
+		c := n.children[i]
+		common := commonPrefix(c.label, seq)
+		// split the edge at the end of the common prefix
+		if common < len(c.label) {
+			mid := &_RadixTreeNode[T]{label: c.label[:common:common], children: []*_RadixTreeNode[T]{c}}
+			c.label = c.label[common:]
+			n.children[i] = mid
+			c = mid
+		}
+		n, seq = c, seq[common:]
+	}
+	n.cnt += cnt
+}
+
+// Remove deletes a sequence of elements from the RadixTree with a given count.
+// If the count is less than or 0, the entire sequence is removed.
+// Or the count is decremented. After that, if the sequence count is less than or 0,
+// the entire sequence is removed too.
+func (t *RadixTree[T]) Remove(cnt int, seq ...T) {
+	// ancestors are the nodes from the root to the parent of n
+	ancestors := make([]*_RadixTreeNode[T], 0)
+	n := t.root
+	for len(seq) > 0 {
+		i := n.child(seq[0])
+		if i < 0 || len(seq) < len(n.children[i].label) ||
+			!slices.Equal(n.children[i].label, seq[:len(n.children[i].label)]) {
+			return
+		}
+		ancestors = append(ancestors, n)
+		n = n.children[i]
+		seq = seq[len(n.label):]
+	}
+	if n.cnt == 0 {
+		return
+	}
+	// remove partially
+	if 0 < cnt && cnt < n.cnt {
+		n.cnt -= cnt
+		return
+	}
+	// remove all
+	n.cnt = 0
+	if n == t.root {
+		return
+	}
+	if len(n.children) == 1 {
+		n.merge()
+		return
+	}
+	// like Trie, the childless ancestors with non-positive counts are removed too
+	for i := len(ancestors) - 1; i >= 0 && len(n.children) == 0 && n.cnt <= 0; i-- {
+		parent := ancestors[i]
+		parent.children = slices.DeleteFunc(parent.children, func(c *_RadixTreeNode[T]) bool { return c == n })
+		n = parent
+	}
+	// the remaining ancestor may become a single-child chain
+	if n != t.root && n.cnt == 0 && len(n.children) == 1 {
+		n.merge()
+	}
+}
+
+// merge absorbs the only child of n into n.
+func (n *_RadixTreeNode[T]) merge() {
+	c := n.children[0]
+	n.label = append(n.label[:len(n.label):len(n.label)], c.label...)
+	n.cnt, n.children = c.cnt, c.children
+}
+
+// Find searches for a sequence of elements in the RadixTree and returns its count.
+func (t *RadixTree[T]) Find(seq ...T) int {
+	n, rest := t.locate(seq)
+	if n == nil || len(rest) > 0 {
+		return 0
+	}
+	return n.cnt
+}
+
+// HasPrefix checks if the RadixTree has the prefix.
+func (t *RadixTree[T]) HasPrefix(prefix ...T) bool {
+	n, _ := t.locate(prefix)
+	return n != nil
+}
+
+// ForEach iterates over all sequences in the RadixTree and applies a consumer function.
+func (t *RadixTree[T]) ForEach(consume func(seq []T, cnt int)) {
+	for seq, cnt := range t.All() {
+		consume(seq, cnt)
+	}
+}
+
+// ForEachPrefix iterates over all sequences in the RadixTree that start with a given prefix.
+func (t *RadixTree[T]) ForEachPrefix(prefix []T, consume func(seq []T, cnt int)) {
+	for seq, cnt := range t.WithPrefix(prefix) {
+		consume(seq, cnt)
+	}
+}
+
+// All returns an iterator over all sequences and their counts,
+// every yielded sequence is a new slice owned by the consumer.
+func (t *RadixTree[T]) All() iter.Seq2[[]T, int] { return t.WithPrefix(nil) }
+
+// WithPrefix returns an iterator over the sequences starting with the prefix,
+// every yielded sequence is a new slice owned by the consumer.
+func (t *RadixTree[T]) WithPrefix(prefix []T) iter.Seq2[[]T, int] {
+	return func(yield func([]T, int) bool) {
+		n, rest := t.locate(prefix)
+		if n == nil {
+			return
+		}
+		t.walk(n, append(slices.Clone(prefix), rest...), yield)
+	}
+}
+
+// locate returns the highest node whose sequence starts with the prefix,
+// and the rest of the node's label after the prefix. n is nil if the prefix does not exist.
+func (t *RadixTree[T]) locate(prefix []T) (n *_RadixTreeNode[T], rest []T) {
+	n = t.root
+	for len(prefix) > 0 {
+		i := n.child(prefix[0])
+		if i < 0 {
+			return nil, nil
+		}
+		n = n.children[i]
+		common := commonPrefix(n.label, prefix)
+		if common == len(prefix) {
+			return n, n.label[common:]
+		}
+		if common < len(n.label) {
+			return nil, nil
+		}
+		prefix = prefix[common:]
+	}
+	return n, nil
+}
+
+// walk recursively traverses the RadixTree, path is the sequence of n and is reused as a buffer.
+func (t *RadixTree[T]) walk(n *_RadixTreeNode[T], path []T, yield func([]T, int) bool) bool {
+	if n.cnt > 0 && !yield(slices.Clone(path), n.cnt) {
+		return false
+	}
+	for _, c := range n.children {
+		if !t.walk(c, append(path, c.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
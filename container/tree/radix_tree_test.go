@@ -0,0 +1,188 @@
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// checkRadixCompressed validates that no chain of single child is left uncompressed
+func checkRadixCompressed[T comparable](t *testing.T, n *_RadixTreeNode[T], isRoot bool) {
+	if !isRoot {
+		require.NotEmpty(t, n.label)
+		require.False(t, n.cnt == 0 && len(n.children) <= 1, "uncompressed node %v", n.label)
+	}
+	for _, c := range n.children {
+		checkRadixCompressed(t, c, false)
+	}
+}
+
+func TestRadixTree(t *testing.T) {
+	// the same cases as Trie
+	radix := NewRadixTree[byte]()
+	radix.Add(3, []byte("apple")...)
+	radix.Add(2, []byte("banana")...)
+	radix.Add(1, []byte("cherry")...)
+	radix.Add(1, []byte("peach")...)
+	radix.Add(1, []byte("pear")...)
+	radix.Add(1, []byte("pineapple")...)
+	radix.Remove(1, []byte("app")...)
+	radix.Remove(1, []byte("apple")...)
+	radix.Remove(1, []byte("peach")...)
+	radix.Remove(0, []byte("banana")...)
+	radix.Remove(0, []byte("orange")...)
+	checkRadixCompressed(t, radix.root, true)
+
+	require.True(t, radix.HasPrefix([]byte("app")...))
+	require.False(t, radix.HasPrefix([]byte("banana")...))
+	require.False(t, radix.HasPrefix([]byte("peac")...))
+	require.True(t, radix.HasPrefix([]byte("cherry")...))
+	require.False(t, radix.HasPrefix([]byte("orange")...))
+
+	require.Equal(t, 2, radix.Find([]byte("apple")...))
+	require.Equal(t, 1, radix.Find([]byte("cherry")...))
+	require.Equal(t, 0, radix.Find([]byte("app")...))
+	require.Equal(t, 0, radix.Find([]byte("banana")...))
+	require.Equal(t, 0, radix.Find([]byte("orange")...))
+
+	m := make(map[string]int)
+	radix.ForEach(func(data []byte, cnt int) { m[string(data)] = cnt })
+	require.Equal(t, map[string]int{"apple": 2, "cherry": 1, "pear": 1, "pineapple": 1}, m)
+	m = make(map[string]int)
+	radix.ForEachPrefix([]byte("pe"), func(data []byte, cnt int) { m[string(data)] = cnt })
+	require.Equal(t, map[string]int{"pear": 1}, m)
+	cnt := 0
+	radix.ForEachPrefix([]byte("x"), func([]byte, int) { cnt++ })
+	require.Equal(t, 0, cnt)
+	for range radix.All() {
+		break
+	}
+}
+
+func TestRadixTree_SameAsTrie(t *testing.T) {
+	// positive counts keep the RadixTree compressed
+	testRadixTreeSameAsTrie(t, func(r *rand.Rand) int { return r.Intn(3) + 1 }, true)
+	// zero and negative counts leave nodes without sequences, the same as Trie
+	testRadixTreeSameAsTrie(t, func(r *rand.Rand) int { return r.Intn(5) - 2 }, false)
+
+	trie, radix := NewTrie[byte](), NewRadixTree[byte]()
+	for _, tree := range []interface{ Add(int, ...byte) }{trie, radix} {
+		tree.Add(-2, []byte("abc")...)
+		tree.Add(-1, []byte("abcc")...)
+	}
+	trie.Remove(-1, []byte("abcc")...)
+	radix.Remove(-1, []byte("abcc")...)
+	require.Equal(t, trie.Find([]byte("abc")...), radix.Find([]byte("abc")...))
+	require.Equal(t, trie.HasPrefix('a'), radix.HasPrefix('a'))
+}
+
+func testRadixTreeSameAsTrie(t *testing.T, count func(r *rand.Rand) int, compressed bool) {
+	r := rand.New(rand.NewSource(1))
+	random := func() []byte {
+		s := make([]byte, r.Intn(6))
+		for i := range s {
+			s[i] = 'a' + byte(r.Intn(3))
+		}
+		return s
+	}
+	trie, radix := NewTrie[byte](), NewRadixTree[byte]()
+	for i := range 2000 {
+		seq, cnt := random(), count(r)
+		if i%3 == 2 {
+			trie.Remove(cnt, seq...)
+			radix.Remove(cnt, seq...)
+		} else {
+			trie.Add(cnt, seq...)
+			radix.Add(cnt, seq...)
+		}
+		if compressed {
+			checkRadixCompressed(t, radix.root, true)
+		}
+		probe := random()
+		require.Equal(t, trie.Find(probe...), radix.Find(probe...), i)
+		require.Equal(t, trie.HasPrefix(probe...), radix.HasPrefix(probe...), i)
+	}
+	for range 100 {
+		prefix := random()
+		expect, got := make(map[string]int), make(map[string]int)
+		trie.ForEachPrefix(prefix, func(seq []byte, cnt int) { expect[string(seq)] = cnt })
+		radix.ForEachPrefix(prefix, func(seq []byte, cnt int) { got[string(seq)] = cnt })
+		require.Equal(t, expect, got, string(prefix))
+	}
+}
+
+// pathKeys generates path-like keys sharing long prefixes
+func pathKeys(n int) [][]byte {
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("/var/lib/service/data/shard-%03d/segment-%08d.log", i%128, i))
+	}
+	return keys
+}
+
+// heapBytes returns the bytes of heap retained by the result of build
+func heapBytes(build func() any) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	ret := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(ret)
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+func BenchmarkTrie_Memory(b *testing.B) {
+	keys := pathKeys(10000)
+	for range b.N {
+		bytes := heapBytes(func() any {
+			trie := NewTrie[byte]()
+			for _, k := range keys {
+				trie.Add(1, k...)
+			}
+			return trie
+		})
+		b.ReportMetric(float64(bytes)/float64(len(keys)), "B/key")
+	}
+}
+
+func BenchmarkRadixTree_Memory(b *testing.B) {
+	keys := pathKeys(10000)
+	for range b.N {
+		bytes := heapBytes(func() any {
+			radix := NewRadixTree[byte]()
+			for _, k := range keys {
+				radix.Add(1, k...)
+			}
+			return radix
+		})
+		b.ReportMetric(float64(bytes)/float64(len(keys)), "B/key")
+	}
+}
+
+func BenchmarkTrie_Find(b *testing.B) {
+	keys := pathKeys(10000)
+	trie := NewTrie[byte]()
+	for _, k := range keys {
+		trie.Add(1, k...)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		trie.Find(keys[i%len(keys)]...)
+	}
+}
+
+func BenchmarkRadixTree_Find(b *testing.B) {
+	keys := pathKeys(10000)
+	radix := NewRadixTree[byte]()
+	for _, k := range keys {
+		radix.Add(1, k...)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		radix.Find(keys[i%len(keys)]...)
+	}
+}
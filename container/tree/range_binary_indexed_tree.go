@@ -0,0 +1,109 @@
+package tree
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+type _Number interface {
+	constraints.Integer | constraints.Float
+}
+
+func fenwickAdd[T _Number](x, y T) T { return x + y }
+
+func fenwickNeg[T _Number](x T) T { return -x }
+
+// differenceArray returns the difference array of the origin,
+// the i-th element is origin[i] - origin[i-1].
+func differenceArray[T _Number](origin []T) []T {
+	diff := make([]T, len(origin))
+	for i := range origin {
+		diff[i] = origin[i]
+		if i > 0 {
+			diff[i] -= origin[i-1]
+		}
+	}
+	return diff
+}
+
+// DualBinaryIndexedTree is a Binary Indexed Tree over the difference array,
+// which supports range update and point query.
+type DualBinaryIndexedTree[T _Number] struct {
+	diff *BinaryIndexedTree[T]
+}
+
+// NewDualBinaryIndexedTree creates and initials a new DualBinaryIndexedTree
+// from a given slice of elements.
+// @Complexity O(n)
+func NewDualBinaryIndexedTree[T _Number](origin []T) *DualBinaryIndexedTree[T] {
+	return &DualBinaryIndexedTree[T]{
+		diff: NewGroupBinaryIndexedTree(differenceArray(origin), fenwickAdd[T], fenwickNeg[T]),
+	}
+}
+
+// Add adds delta to every element within the range [l, r].
+// @Complexity O(log(n))
+func (bit *DualBinaryIndexedTree[T]) Add(l, r int, delta T) {
+	bit.diff.Renew(l, func(e T) T { return e + delta })
+	if r+1 < len(bit.diff.tree) {
+		bit.diff.Renew(r+1, func(e T) T { return e - delta })
+	}
+}
+
+// Get returns the element at the given index i.
+// @Complexity O(log(n))
+func (bit *DualBinaryIndexedTree[T]) Get(i int) T { return bit.diff.Accumulate(i) }
+
+// RangeBinaryIndexedTree keeps two Binary Indexed Trees over the difference array d,
+// one of d[i] and one of d[i]*i, which supports both range update and range query.
+type RangeBinaryIndexedTree[T _Number] struct {
+	diff, weighted *BinaryIndexedTree[T]
+}
+
+// NewRangeBinaryIndexedTree creates and initials a new RangeBinaryIndexedTree
+// from a given slice of elements.
+// @Complexity O(n)
+func NewRangeBinaryIndexedTree[T _Number](origin []T) *RangeBinaryIndexedTree[T] {
+	diff := differenceArray(origin)
+	weighted := make([]T, len(diff))
+	for i := range diff {
+		weighted[i] = diff[i] * T(i)
+	}
+	return &RangeBinaryIndexedTree[T]{
+		diff:     NewGroupBinaryIndexedTree(diff, fenwickAdd[T], fenwickNeg[T]),
+		weighted: NewGroupBinaryIndexedTree(weighted, fenwickAdd[T], fenwickNeg[T]),
+	}
+}
+
+// Add adds delta to every element within the range [l, r].
+// @Complexity O(log(n))
+func (bit *RangeBinaryIndexedTree[T]) Add(l, r int, delta T) {
+	bit.add(l, delta)
+	if r+1 < len(bit.diff.tree) {
+		bit.add(r+1, -delta)
+	}
+}
+
+func (bit *RangeBinaryIndexedTree[T]) add(i int, delta T) {
+	bit.diff.Renew(i, func(e T) T { return e + delta })
+	bit.weighted.Renew(i, func(e T) T { return e + delta*T(i) })
+}
+
+// Accumulate calculates the sum of elements up to the given index i.
+// The sum of a[0..i] equals sum(d[j] * (i+1-j)), which is (i+1)*sum(d[j]) - sum(d[j]*j).
+// @Complexity O(log(n))
+func (bit *RangeBinaryIndexedTree[T]) Accumulate(i int) T {
+	return bit.diff.Accumulate(i)*T(i+1) - bit.weighted.Accumulate(i)
+}
+
+// Sum returns the sum of elements within the range [l, r].
+// @Complexity O(log(n))
+func (bit *RangeBinaryIndexedTree[T]) Sum(l, r int) T {
+	if l == 0 {
+		return bit.Accumulate(r)
+	}
+	return bit.Accumulate(r) - bit.Accumulate(l-1)
+}
+
+// Get returns the element at the given index i.
+// @Complexity O(log(n))
+func (bit *RangeBinaryIndexedTree[T]) Get(i int) T { return bit.diff.Accumulate(i) }
@@ -0,0 +1,49 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDualBinaryIndexedTree(t *testing.T) {
+	origin := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	bit := NewDualBinaryIndexedTree(append([]int(nil), origin...))
+	r := rand.New(rand.NewSource(1))
+	for range 200 {
+		l := r.Intn(len(origin))
+		rr := l + r.Intn(len(origin)-l)
+		delta := r.Intn(21) - 10
+		bit.Add(l, rr, delta)
+		for i := l; i <= rr; i++ {
+			origin[i] += delta
+		}
+		for i := range origin {
+			require.Equal(t, origin[i], bit.Get(i))
+		}
+	}
+}
+
+func TestRangeBinaryIndexedTree(t *testing.T) {
+	origin := []int64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}
+	bit := NewRangeBinaryIndexedTree(append([]int64(nil), origin...))
+	r := rand.New(rand.NewSource(1))
+	for range 200 {
+		l := r.Intn(len(origin))
+		rr := l + r.Intn(len(origin)-l)
+		delta := int64(r.Intn(21) - 10)
+		bit.Add(l, rr, delta)
+		for i := l; i <= rr; i++ {
+			origin[i] += delta
+		}
+		for i := range origin {
+			require.Equal(t, origin[i], bit.Get(i))
+			sum := int64(0)
+			for j := i; j < len(origin); j++ {
+				sum += origin[j]
+				require.Equal(t, sum, bit.Sum(i, j), i, j)
+			}
+		}
+	}
+}
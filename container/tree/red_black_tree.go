@@ -0,0 +1,276 @@
+package tree
+
+import (
+	"iter"
+)
+
+type _RedBlackTreeNode[K, V any] struct {
+	key K
+	val V
+	// red means the link from the parent is red
+	red bool
+	// size is the subtree's node count
+	size        int
+	left, right *_RedBlackTreeNode[K, V]
+}
+
+func (n *_RedBlackTreeNode[K, V]) isRed() bool { return n != nil && n.red }
+
+func (n *_RedBlackTreeNode[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// maintain updates the node's size based on its children
+func (n *_RedBlackTreeNode[K, V]) maintain() { n.size = n.left.getSize() + n.right.getSize() + 1 }
+
+// leftRotate turns a right-leaning red link to lean left, double lines are red links
+//
+//	  |           |
+//	  A           C
+//	 / \\   =>   // \
+//	B   C       A   E
+//	   / \     / \
+//	  D   E   B   D
+func (n *_RedBlackTreeNode[K, V]) leftRotate() *_RedBlackTreeNode[K, V] {
+	newRoot := n.right
+	n.right = newRoot.left
+	newRoot.left = n
+	newRoot.red, n.red = n.red, true
+	n.maintain()
+	newRoot.maintain()
+	return newRoot
+}
+
+// rightRotate turns a left-leaning red link to lean right, double lines are red links
+//
+//	    |           |
+//	    A           B
+//	   // \   =>   / \\
+//	  B   C       D   A
+//	 / \             / \
+//	D   E           E   C
+func (n *_RedBlackTreeNode[K, V]) rightRotate() *_RedBlackTreeNode[K, V] {
+	newRoot := n.left
+	n.left = newRoot.right
+	newRoot.right = n
+	newRoot.red, n.red = n.red, true
+	n.maintain()
+	newRoot.maintain()
+	return newRoot
+}
+
+// flipColors flips the colors of the node and its children, which splits or merges a 4-node
+func (n *_RedBlackTreeNode[K, V]) flipColors() {
+	n.red = !n.red
+	n.left.red = !n.left.red
+	n.right.red = !n.right.red
+}
+
+// reBalance restores the left-leaning invariants on the way up
+func (n *_RedBlackTreeNode[K, V]) reBalance() *_RedBlackTreeNode[K, V] {
+	if n.right.isRed() && !n.left.isRed() {
+		n = n.leftRotate()
+	}
+	if n.left.isRed() && n.left.left.isRed() {
+		n = n.rightRotate()
+	}
+	if n.left.isRed() && n.right.isRed() {
+		n.flipColors()
+	}
+	n.maintain()
+	return n
+}
+
+// moveRedLeft makes the left child or one of its children red, assuming n is red
+func (n *_RedBlackTreeNode[K, V]) moveRedLeft() *_RedBlackTreeNode[K, V] {
+	n.flipColors()
+	if n.right.left.isRed() {
+		n.right = n.right.rightRotate()
+		n = n.leftRotate()
+		n.flipColors()
+	}
+	return n
+}
+
+// moveRedRight makes the right child or one of its children red, assuming n is red
+func (n *_RedBlackTreeNode[K, V]) moveRedRight() *_RedBlackTreeNode[K, V] {
+	n.flipColors()
+	if n.left.left.isRed() {
+		n = n.rightRotate()
+		n.flipColors()
+	}
+	return n
+}
+
+// removeMin deletes the leftmost node of the subtree
+func (n *_RedBlackTreeNode[K, V]) removeMin() *_RedBlackTreeNode[K, V] {
+	if n.left == nil {
+		return nil
+	}
+	if !n.left.isRed() && !n.left.left.isRed() {
+		n = n.moveRedLeft()
+	}
+	n.left = n.left.removeMin()
+	return n.reBalance()
+}
+
+func (n *_RedBlackTreeNode[K, V]) inorder(yield func(K, V) bool) bool {
+	if n.left != nil && !n.left.inorder(yield) {
+		return false
+	}
+	if !yield(n.key, n.val) {
+		return false
+	}
+	if n.right != nil && !n.right.inorder(yield) {
+		return false
+	}
+	return true
+}
+
+// RedBlackTree represents a self-balancing binary search tree using left-leaning red-black algorithm.
+// It is balanced less strictly than AvlTree, the height is up to 2*log(n).
+type RedBlackTree[K, V any] struct {
+	root *_RedBlackTreeNode[K, V]
+	cmp  func(a, b K) int
+}
+
+// NewRedBlackTree creates an empty red-black tree with a given comparison function
+func NewRedBlackTree[K, V any](cmp func(a, b K) int) *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{
+		root: nil,
+		cmp:  cmp,
+	}
+}
+
+// Size returns the total number of elements.
+// Complexity: O(1)
+func (rb *RedBlackTree[K, V]) Size() int { return rb.root.getSize() }
+
+// Get searches for a value and returns (value, true) if found
+// Complexity: O(log(n))
+func (rb *RedBlackTree[K, V]) Get(key K) (val V, ok bool) {
+	for node := rb.root; node != nil; {
+		c := rb.cmp(key, node.key)
+		if c == 0 {
+			return node.val, true
+		}
+		if c < 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return val, false
+}
+
+// Contains returns existence of a value
+// Complexity: O(log(n))
+func (rb *RedBlackTree[K, V]) Contains(key K) bool {
+	_, ok := rb.Get(key)
+	return ok
+}
+
+// Rank finds the k-th smallest element (1-based index)
+// Complexity: O(log(n))
+func (rb *RedBlackTree[K, V]) Rank(k int) (val V, ok bool) {
+	if k <= 0 || k > rb.Size() {
+		return val, false
+	}
+	for n := rb.root; ; {
+		leftSize := n.left.getSize()
+		if k == leftSize+1 {
+			return n.val, true
+		}
+		if k <= leftSize {
+			n = n.left
+			continue
+		}
+		n = n.right
+		k -= leftSize + 1
+	}
+}
+
+// Set inserts a value or updates if exists through compare function
+// Complexity: O(log(n))
+func (rb *RedBlackTree[K, V]) Set(key K, val V) {
+	rb.root = rb.set(rb.root, key, val)
+	rb.root.red = false
+}
+
+func (rb *RedBlackTree[K, V]) set(n *_RedBlackTreeNode[K, V], key K, val V) *_RedBlackTreeNode[K, V] {
+	if n == nil {
+		return &_RedBlackTreeNode[K, V]{key: key, val: val, red: true, size: 1}
+	}
+	c := rb.cmp(key, n.key)
+	// renew value when equal
+	if c == 0 {
+		n.val = val
+		return n
+	}
+	if c < 0 {
+		n.left = rb.set(n.left, key, val)
+	} else {
+		n.right = rb.set(n.right, key, val)
+	}
+	return n.reBalance()
+}
+
+// Remove deletes a value from the tree if exists
+// Complexity: O(log(n))
+func (rb *RedBlackTree[K, V]) Remove(key K) {
+	if !rb.Contains(key) {
+		return
+	}
+	if !rb.root.left.isRed() && !rb.root.right.isRed() {
+		rb.root.red = true
+	}
+	rb.root = rb.remove(rb.root, key)
+	if rb.root != nil {
+		rb.root.red = false
+	}
+}
+
+// remove deletes the key which must exist in the subtree
+func (rb *RedBlackTree[K, V]) remove(n *_RedBlackTreeNode[K, V], key K) *_RedBlackTreeNode[K, V] {
+	if rb.cmp(key, n.key) < 0 {
+		if !n.left.isRed() && !n.left.left.isRed() {
+			n = n.moveRedLeft()
+		}
+		n.left = rb.remove(n.left, key)
+		return n.reBalance()
+	}
+	if n.left.isRed() {
+		n = n.rightRotate()
+	}
+	if rb.cmp(key, n.key) == 0 && n.right == nil {
+		return nil
+	}
+	if !n.right.isRed() && !n.right.left.isRed() {
+		n = n.moveRedRight()
+	}
+	if rb.cmp(key, n.key) == 0 {
+		// replace by the successor, which is the leftmost node in n's right subtree
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.key, n.val = successor.key, successor.val
+		n.right = n.right.removeMin()
+	} else {
+		n.right = rb.remove(n.right, key)
+	}
+	return n.reBalance()
+}
+
+// Iter provides an in-order traversal iterator
+func (rb *RedBlackTree[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if rb.root == nil {
+			return
+		}
+		rb.root.inorder(yield)
+	}
+}
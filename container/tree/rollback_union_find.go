@@ -0,0 +1,138 @@
+package tree
+
+// _RollbackRecord is a modification of RollbackUnionFind which can be undone
+type _RollbackRecord[T comparable] struct {
+	// added means elem is newly added, otherwise elem is attached to root
+	added bool
+	elem  T
+	root  T
+	// rankUp means the rank of root is increased by the union
+	rankUp bool
+	// serial is unique among all records ever made, starting from 1
+	serial int
+}
+
+// RollbackSnapshot is a version of RollbackUnionFind returned by Snapshot.
+type RollbackSnapshot struct {
+	depth int
+	// last is the serial of the last record at the version, 0 if there is no record
+	last int
+}
+
+// RollbackUnionFind is a union-find whose modifications can be undone.
+// It uses union by rank without path compression, so Find costs O(log(n)).
+type RollbackUnionFind[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	// size is the element count of the set, only valid for roots
+	size map[T]int
+	// connected component count
+	cnt     int
+	history []_RollbackRecord[T]
+	// serial is the number of records ever made
+	serial int
+}
+
+// NewRollbackUnionFind creates and returns a new instance of RollbackUnionFind.
+func NewRollbackUnionFind[T comparable]() *RollbackUnionFind[T] {
+	return &RollbackUnionFind[T]{
+		parent:  make(map[T]T),
+		rank:    make(map[T]int),
+		size:    make(map[T]int),
+		cnt:     0,
+		history: make([]_RollbackRecord[T], 0),
+	}
+}
+
+// Find returns the root of the element e.
+// @Complexity O(log(n))
+func (uf *RollbackUnionFind[T]) Find(e T) T {
+	if _, ok := uf.parent[e]; !ok {
+		uf.parent[e] = e
+		uf.rank[e] = 0
+		uf.size[e] = 1
+		uf.cnt++
+		uf.record(_RollbackRecord[T]{added: true, elem: e})
+		return e
+	}
+	for uf.parent[e] != e {
+		e = uf.parent[e]
+	}
+	return e
+}
+
+// IsConnect checks if two elements are in the same set.
+// @Complexity O(log(n))
+func (uf *RollbackUnionFind[T]) IsConnect(e1, e2 T) bool { return uf.Find(e1) == uf.Find(e2) }
+
+// Union merges the sets containing elements e1 and e2,
+// the root with lower rank is attached to the higher one.
+// @Complexity O(log(n))
+func (uf *RollbackUnionFind[T]) Union(e1, e2 T) {
+	r1, r2 := uf.Find(e1), uf.Find(e2)
+	if r1 == r2 {
+		return
+	}
+	if uf.rank[r1] > uf.rank[r2] {
+		r1, r2 = r2, r1
+	}
+	record := _RollbackRecord[T]{elem: r1, root: r2, rankUp: uf.rank[r1] == uf.rank[r2]}
+	uf.parent[r1] = r2
+	uf.size[r2] += uf.size[r1]
+	if record.rankUp {
+		uf.rank[r2]++
+	}
+	uf.cnt--
+	uf.record(record)
+}
+
+// record appends the modification to the history with a new serial.
+func (uf *RollbackUnionFind[T]) record(r _RollbackRecord[T]) {
+	uf.serial++
+	r.serial = uf.serial
+	uf.history = append(uf.history, r)
+}
+
+// ConnectedComponent returns the number of connected components in the union-find.
+func (uf *RollbackUnionFind[T]) ConnectedComponent() int { return uf.cnt }
+
+// SizeOf returns the number of elements in the set containing e.
+// @Complexity O(log(n))
+func (uf *RollbackUnionFind[T]) SizeOf(e T) int { return uf.size[uf.Find(e)] }
+
+// Snapshot returns the current version, which can be restored by Rollback.
+func (uf *RollbackUnionFind[T]) Snapshot() RollbackSnapshot {
+	snapshot := RollbackSnapshot{depth: len(uf.history)}
+	if len(uf.history) > 0 {
+		snapshot.last = uf.history[len(uf.history)-1].serial
+	}
+	return snapshot
+}
+
+// Rollback undoes all modifications after the snapshot, including unions and added elements.
+// It panics when the snapshot is invalid, that is its modifications are already rolled back,
+// even if new modifications are made since then.
+// @Complexity O(k), k is the number of undone modifications
+func (uf *RollbackUnionFind[T]) Rollback(snapshot RollbackSnapshot) {
+	if snapshot.depth > len(uf.history) ||
+		snapshot.depth > 0 && uf.history[snapshot.depth-1].serial != snapshot.last {
+		panic("invalid snapshot")
+	}
+	for i := len(uf.history) - 1; i >= snapshot.depth; i-- {
+		record := uf.history[i]
+		if record.added {
+			delete(uf.parent, record.elem)
+			delete(uf.rank, record.elem)
+			delete(uf.size, record.elem)
+			uf.cnt--
+		} else {
+			uf.parent[record.elem] = record.elem
+			uf.size[record.root] -= uf.size[record.elem]
+			if record.rankUp {
+				uf.rank[record.root]--
+			}
+			uf.cnt++
+		}
+	}
+	uf.history = uf.history[:snapshot.depth]
+}
@@ -0,0 +1,116 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackUnionFind(t *testing.T) {
+	uf := NewRollbackUnionFind[int]()
+	s0 := uf.Snapshot()
+	uf.Union(1, 2)
+	uf.Union(3, 4)
+	s1 := uf.Snapshot()
+	uf.Union(2, 4)
+	uf.Union(5, 6)
+	require.True(t, uf.IsConnect(1, 3))
+	require.Equal(t, 4, uf.SizeOf(1))
+	require.Equal(t, 2, uf.ConnectedComponent())
+
+	// undo 2-4 and 5-6
+	uf.Rollback(s1)
+	require.False(t, uf.IsConnect(1, 3))
+	require.True(t, uf.IsConnect(1, 2))
+	require.True(t, uf.IsConnect(3, 4))
+	require.Equal(t, 2, uf.SizeOf(1))
+	require.Equal(t, 2, uf.ConnectedComponent())
+	// 5 and 6 are removed, IsConnect adds them again
+	require.False(t, uf.IsConnect(5, 6))
+	require.Equal(t, 4, uf.ConnectedComponent())
+
+	// redo after rollback
+	uf.Union(1, 4)
+	require.True(t, uf.IsConnect(2, 3))
+	require.Equal(t, 3, uf.ConnectedComponent())
+
+	uf.Rollback(s0)
+	require.Equal(t, 0, uf.ConnectedComponent())
+	require.Equal(t, s0, uf.Snapshot())
+	require.Panics(t, func() { uf.Rollback(s1) })
+}
+
+func TestRollbackUnionFind_StaleSnapshot(t *testing.T) {
+	uf := NewRollbackUnionFind[int]()
+	empty := uf.Snapshot()
+	uf.Union(1, 2)
+	s := uf.Snapshot()
+	uf.Rollback(empty)
+	// the history grows deeper than s again with different modifications
+	uf.Union(3, 4)
+	uf.Union(5, 6)
+	require.Panics(t, func() { uf.Rollback(s) })
+	require.True(t, uf.IsConnect(5, 6))
+
+	// an earlier snapshot is still valid after rolling back to a later one
+	uf = NewRollbackUnionFind[int]()
+	uf.Union(1, 2)
+	s0 := uf.Snapshot()
+	uf.Union(3, 4)
+	s1 := uf.Snapshot()
+	uf.Union(5, 6)
+	uf.Rollback(s1)
+	uf.Union(7, 8)
+	uf.Rollback(s1)
+	uf.Rollback(s0)
+	require.True(t, uf.IsConnect(1, 2))
+	require.False(t, uf.IsConnect(3, 4))
+}
+
+func TestRollbackUnionFind_Offline(t *testing.T) {
+	// divide and conquer over edges, each edge is alive within [l, r) of the timeline
+	type edge struct{ u, v, l, r int }
+	edges := []edge{{0, 1, 0, 4}, {1, 2, 1, 3}, {2, 3, 2, 6}, {0, 3, 5, 6}, {1, 3, 3, 5}}
+	const timeline = 6
+	expect := make([]int, timeline)
+	for tm := range timeline {
+		naive := NewUnionFind[int]()
+		for i := range 4 {
+			naive.Find(i)
+		}
+		for _, e := range edges {
+			if e.l <= tm && tm < e.r {
+				naive.Union(e.u, e.v)
+			}
+		}
+		expect[tm] = naive.ConnectedComponent()
+	}
+
+	uf := NewRollbackUnionFind[int]()
+	for i := range 4 {
+		uf.Find(i)
+	}
+	got := make([]int, timeline)
+	var solve func(l, r int, es []edge)
+	solve = func(l, r int, es []edge) {
+		snapshot := uf.Snapshot()
+		rest := make([]edge, 0, len(es))
+		for _, e := range es {
+			if e.l <= l && r <= e.r {
+				uf.Union(e.u, e.v)
+			} else if e.l < r && l < e.r {
+				rest = append(rest, e)
+			}
+		}
+		if r-l == 1 {
+			got[l] = uf.ConnectedComponent()
+		} else {
+			mid := (l + r) / 2
+			solve(l, mid, rest)
+			solve(mid, r, rest)
+		}
+		uf.Rollback(snapshot)
+	}
+	solve(0, timeline, edges)
+	require.Equal(t, expect, got)
+}
@@ -0,0 +1,250 @@
+package tree
+
+import (
+	"math/bits"
+)
+
+// SegmentTree represents a segment tree with lazy propagation.
+// Values form a monoid (identity, merge), and lazy tags of type L are applied to segments.
+type SegmentTree[T, L any] struct {
+	n, size, log int
+	data         []T
+	lazy         []L
+	identity     T
+	merge        func(T, T) T
+	tagIdentity  L
+	apply        func(L, T, int) T
+	compose      func(L, L) L
+}
+
+// NewSegmentTree creates and initials a new segment tree from a given slice of elements.
+// @Param identity and merge define the monoid of elements, merge must be associative.
+// @Param tagIdentity is the tag changing nothing.
+// @Param apply returns the merged value of a segment of length size after applying tag to it.
+// @Param compose returns the tag equivalent to applying older first and then newer.
+// @Complexity O(n)
+func NewSegmentTree[T, L any](origin []T, identity T, merge func(x, y T) T,
+	tagIdentity L, apply func(tag L, val T, size int) T, compose func(newer, older L) L) *SegmentTree[T, L] {
+	log := 0
+	if len(origin) > 1 {
+		log = bits.Len(uint(len(origin) - 1))
+	}
+	st := &SegmentTree[T, L]{
+		n:           len(origin),
+		size:        1 << log,
+		log:         log,
+		identity:    identity,
+		merge:       merge,
+		tagIdentity: tagIdentity,
+		apply:       apply,
+		compose:     compose,
+	}
+	st.data = make([]T, 2*st.size)
+	st.lazy = make([]L, st.size)
+	for i := range st.data {
+		st.data[i] = identity
+	}
+	for i := range st.lazy {
+		st.lazy[i] = tagIdentity
+	}
+	copy(st.data[st.size:], origin)
+	for i := st.size - 1; i >= 1; i-- {
+		st.update(i)
+	}
+	return st
+}
+
+// Size returns the number of elements.
+func (st *SegmentTree[T, L]) Size() int { return st.n }
+
+// Get returns the element at the given index i, it panics when i is out of [0, n).
+// @Complexity O(log(n))
+func (st *SegmentTree[T, L]) Get(i int) T {
+	st.checkRange(i, i)
+	i += st.size
+	for j := st.log; j >= 1; j-- {
+		st.push(i >> j)
+	}
+	return st.data[i]
+}
+
+// Set assigns the element at the given index i, it panics when i is out of [0, n).
+// @Complexity O(log(n))
+func (st *SegmentTree[T, L]) Set(i int, val T) {
+	st.checkRange(i, i)
+	i += st.size
+	for j := st.log; j >= 1; j-- {
+		st.push(i >> j)
+	}
+	st.data[i] = val
+	for j := 1; j <= st.log; j++ {
+		st.update(i >> j)
+	}
+}
+
+// Query returns the merged value within the range [l, r], identity if the range is empty.
+// It panics when a non-empty range is out of [0, n).
+// @Complexity O(log(n))
+func (st *SegmentTree[T, L]) Query(l, r int) T {
+	if l > r {
+		return st.identity
+	}
+	st.checkRange(l, r)
+	l, r = l+st.size, r+1+st.size
+	st.pushBoundary(l, r)
+	left, right := st.identity, st.identity
+	for ; l < r; l, r = l>>1, r>>1 {
+		if l&1 == 1 {
+			left = st.merge(left, st.data[l])
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			right = st.merge(st.data[r], right)
+		}
+	}
+	return st.merge(left, right)
+}
+
+// Update applies the tag to every element within the range [l, r].
+// It panics when a non-empty range is out of [0, n).
+// @Complexity O(log(n))
+func (st *SegmentTree[T, L]) Update(l, r int, tag L) {
+	if l > r {
+		return
+	}
+	st.checkRange(l, r)
+	l, r = l+st.size, r+1+st.size
+	st.pushBoundary(l, r)
+	for l2, r2 := l, r; l2 < r2; l2, r2 = l2>>1, r2>>1 {
+		if l2&1 == 1 {
+			st.applyNode(l2, tag)
+			l2++
+		}
+		if r2&1 == 1 {
+			r2--
+			st.applyNode(r2, tag)
+		}
+	}
+	for j := 1; j <= st.log; j++ {
+		if (l>>j)<<j != l {
+			st.update(l >> j)
+		}
+		if (r>>j)<<j != r {
+			st.update((r - 1) >> j)
+		}
+	}
+}
+
+// MaxRight returns the largest r such that pred(Query(l, r)) is true, or l-1 if there is none.
+// pred must be true for identity and monotone, which means once false it keeps false as r grows.
+// It panics when l is out of [0, n].
+// @Complexity O(log(n))
+func (st *SegmentTree[T, L]) MaxRight(l int, pred func(T) bool) int {
+	st.checkRange(l, l-1)
+	if l == st.n {
+		return st.n - 1
+	}
+	l += st.size
+	for j := st.log; j >= 1; j-- {
+		st.push(l >> j)
+	}
+	acc := st.identity
+	for {
+		for l&1 == 0 {
+			l >>= 1
+		}
+		if !pred(st.merge(acc, st.data[l])) {
+			// descend to the first leaf making pred false
+			for l < st.size {
+				st.push(l)
+				l <<= 1
+				if next := st.merge(acc, st.data[l]); pred(next) {
+					acc = next
+					l++
+				}
+			}
+			return l - st.size - 1
+		}
+		acc = st.merge(acc, st.data[l])
+		l++
+		if l&-l == l {
+			return st.n - 1
+		}
+	}
+}
+
+// MinLeft returns the smallest l such that pred(Query(l, r)) is true, or r+1 if there is none.
+// pred must be true for identity and monotone, which means once false it keeps false as l shrinks.
+// It panics when r is out of [-1, n).
+// @Complexity O(log(n))
+func (st *SegmentTree[T, L]) MinLeft(r int, pred func(T) bool) int {
+	st.checkRange(r+1, r)
+	if r < 0 {
+		return 0
+	}
+	r += st.size + 1
+	for j := st.log; j >= 1; j-- {
+		st.push((r - 1) >> j)
+	}
+	acc := st.identity
+	for {
+		r--
+		for r > 1 && r&1 == 1 {
+			r >>= 1
+		}
+		if !pred(st.merge(st.data[r], acc)) {
+			// descend to the last leaf making pred false
+			for r < st.size {
+				st.push(r)
+				r = r<<1 | 1
+				if next := st.merge(st.data[r], acc); pred(next) {
+					acc = next
+					r--
+				}
+			}
+			return r + 1 - st.size
+		}
+		acc = st.merge(st.data[r], acc)
+		if r&-r == r {
+			return 0
+		}
+	}
+}
+
+// checkRange panics when [l, r] is not within [0, n), the empty range [l, l-1] is allowed for l in [0, n].
+func (st *SegmentTree[T, L]) checkRange(l, r int) {
+	if l < 0 || r >= st.n || l > r+1 {
+		panic("index out of range")
+	}
+}
+
+// update recomputes the node k from its children.
+func (st *SegmentTree[T, L]) update(k int) { st.data[k] = st.merge(st.data[k<<1], st.data[k<<1|1]) }
+
+// applyNode applies the tag to the node k and records it for the children.
+func (st *SegmentTree[T, L]) applyNode(k int, tag L) {
+	st.data[k] = st.apply(tag, st.data[k], st.size>>(bits.Len(uint(k))-1))
+	if k < st.size {
+		st.lazy[k] = st.compose(tag, st.lazy[k])
+	}
+}
+
+// push propagates the pending tag of the node k to its children.
+func (st *SegmentTree[T, L]) push(k int) {
+	st.applyNode(k<<1, st.lazy[k])
+	st.applyNode(k<<1|1, st.lazy[k])
+	st.lazy[k] = st.tagIdentity
+}
+
+// pushBoundary propagates the pending tags above the leaf range [l, r).
+func (st *SegmentTree[T, L]) pushBoundary(l, r int) {
+	for j := st.log; j >= 1; j-- {
+		if (l>>j)<<j != l {
+			st.push(l >> j)
+		}
+		if (r>>j)<<j != r {
+			st.push((r - 1) >> j)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package tree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentTree_AddMin(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	origin := make([]int, 37)
+	for i := range origin {
+		origin[i] = r.Intn(100)
+	}
+	st := NewSegmentTree(append([]int(nil), origin...), math.MaxInt, func(x, y int) int { return min(x, y) },
+		0, func(tag, val, _ int) int {
+			if val == math.MaxInt {
+				return val
+			}
+			return val + tag
+		}, func(newer, older int) int { return newer + older })
+	require.Equal(t, len(origin), st.Size())
+	for range 500 {
+		l := r.Intn(len(origin))
+		rr := l + r.Intn(len(origin)-l)
+		switch r.Intn(3) {
+		case 0:
+			delta := r.Intn(21) - 10
+			st.Update(l, rr, delta)
+			for i := l; i <= rr; i++ {
+				origin[i] += delta
+			}
+		case 1:
+			v := r.Intn(100)
+			st.Set(l, v)
+			origin[l] = v
+		case 2:
+			m := math.MaxInt
+			for i := l; i <= rr; i++ {
+				m = min(m, origin[i])
+			}
+			require.Equal(t, m, st.Query(l, rr), l, rr)
+			require.Equal(t, origin[l], st.Get(l))
+		}
+	}
+	require.Equal(t, math.MaxInt, st.Query(3, 2))
+}
+
+// _AssignAdd assigns first when set is true, then adds
+type _AssignAdd struct {
+	set      bool
+	assigned int
+	add      int
+}
+
+func TestSegmentTree_AssignAddSum(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	origin := make([]int, 50)
+	for i := range origin {
+		origin[i] = r.Intn(10)
+	}
+	st := NewSegmentTree(append([]int(nil), origin...), 0, func(x, y int) int { return x + y },
+		_AssignAdd{}, func(tag _AssignAdd, val, size int) int {
+			if tag.set {
+				val = tag.assigned * size
+			}
+			return val + tag.add*size
+		}, func(newer, older _AssignAdd) _AssignAdd {
+			if newer.set {
+				return newer
+			}
+			older.add += newer.add
+			return older
+		})
+	for range 500 {
+		l := r.Intn(len(origin))
+		rr := l + r.Intn(len(origin)-l)
+		switch r.Intn(4) {
+		case 0:
+			v := r.Intn(10)
+			st.Update(l, rr, _AssignAdd{set: true, assigned: v})
+			for i := l; i <= rr; i++ {
+				origin[i] = v
+			}
+		case 1:
+			d := r.Intn(5)
+			st.Update(l, rr, _AssignAdd{add: d})
+			for i := l; i <= rr; i++ {
+				origin[i] += d
+			}
+		case 2:
+			sum := 0
+			for i := l; i <= rr; i++ {
+				sum += origin[i]
+			}
+			require.Equal(t, sum, st.Query(l, rr), l, rr)
+		case 3:
+			// binary search on prefix and suffix sums
+			limit := r.Intn(100)
+			pred := func(v int) bool { return v <= limit }
+			expect, sum := l-1, 0
+			for i := l; i < len(origin); i++ {
+				if sum += origin[i]; sum > limit {
+					break
+				}
+				expect = i
+			}
+			require.Equal(t, expect, st.MaxRight(l, pred), l, limit)
+			expect, sum = rr+1, 0
+			for i := rr; i >= 0; i-- {
+				if sum += origin[i]; sum > limit {
+					break
+				}
+				expect = i
+			}
+			require.Equal(t, expect, st.MinLeft(rr, pred), rr, limit)
+		}
+	}
+	require.Equal(t, len(origin)-1, st.MaxRight(len(origin), func(int) bool { return false }))
+	require.Equal(t, 0, st.MinLeft(-1, func(int) bool { return false }))
+}
+
+func TestSegmentTree_Single(t *testing.T) {
+	st := NewSegmentTree([]int{5}, 0, func(x, y int) int { return x + y },
+		0, func(tag, val, size int) int { return val + tag*size }, func(newer, older int) int { return newer + older })
+	st.Update(0, 0, 2)
+	require.Equal(t, 7, st.Query(0, 0))
+	require.Equal(t, 0, st.MaxRight(0, func(v int) bool { return v < 10 }))
+	require.Equal(t, -1, st.MaxRight(0, func(v int) bool { return v < 5 }))
+	require.Equal(t, 0, st.MinLeft(0, func(v int) bool { return v < 10 }))
+	require.Equal(t, 1, st.MinLeft(0, func(v int) bool { return v < 5 }))
+}
+
+func TestSegmentTree_OutOfRange(t *testing.T) {
+	// the leaf of index 3 is padding
+	st := NewSegmentTree([]int{1, 2, 3}, 0, func(x, y int) int { return x + y },
+		0, func(tag, val, size int) int { return val + tag*size }, func(newer, older int) int { return newer + older })
+	require.Panics(t, func() { st.Set(3, 4) })
+	require.Panics(t, func() { st.Set(-1, 4) })
+	require.Panics(t, func() { st.Get(3) })
+	require.Panics(t, func() { st.Query(1, 3) })
+	require.Panics(t, func() { st.Update(-1, 1, 1) })
+	require.Panics(t, func() { st.MaxRight(4, func(int) bool { return true }) })
+	require.Panics(t, func() { st.MinLeft(3, func(int) bool { return true }) })
+	require.Equal(t, 6, st.Query(0, 2))
+	require.Equal(t, 0, st.Query(3, 2))
+	require.Equal(t, 2, st.MaxRight(3, func(int) bool { return true }))
+	require.Equal(t, 0, st.MinLeft(-1, func(int) bool { return true }))
+}
@@ -0,0 +1,185 @@
+package tree
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+// skipListMaxLevel is enough for 4^32 elements with the promotion probability 1/4
+const skipListMaxLevel = 32
+
+type _SkipListNode[K, V any] struct {
+	key  K
+	val  V
+	next []*_SkipListNode[K, V]
+	// span[i] is the number of steps on level 0 from the node to next[i]
+	span []int
+}
+
+func newSkipListNode[K, V any](key K, val V, level int) *_SkipListNode[K, V] {
+	return &_SkipListNode[K, V]{
+		key:  key,
+		val:  val,
+		next: make([]*_SkipListNode[K, V], level),
+		span: make([]int, level),
+	}
+}
+
+// SkipList represents an ordered map using probabilistic multi-level linked lists.
+// It needs no rotation on writes, and the expected complexity of operations is O(log(n)).
+type SkipList[K, V any] struct {
+	head *_SkipListNode[K, V]
+	// level is the number of levels in use
+	level int
+	size  int
+	cmp   func(a, b K) int
+}
+
+// NewSkipList creates an empty skip list with a given comparison function
+func NewSkipList[K, V any](cmp func(a, b K) int) *SkipList[K, V] {
+	var key K
+	var val V
+	return &SkipList[K, V]{
+		head:  newSkipListNode(key, val, skipListMaxLevel),
+		level: 1,
+		size:  0,
+		cmp:   cmp,
+	}
+}
+
+// randomLevel returns a level in [1, skipListMaxLevel], promoting with the probability 1/4
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.IntN(4) == 0 {
+		level++
+	}
+	return level
+}
+
+// Size returns the total number of elements.
+// Complexity: O(1)
+func (sl *SkipList[K, V]) Size() int { return sl.size }
+
+// Get searches for a value and returns (value, true) if found
+// Complexity: O(log(n)) expected
+func (sl *SkipList[K, V]) Get(key K) (val V, ok bool) {
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && sl.cmp(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+	}
+	if x = x.next[0]; x != nil && sl.cmp(x.key, key) == 0 {
+		return x.val, true
+	}
+	return val, false
+}
+
+// Contains returns existence of a value
+// Complexity: O(log(n)) expected
+func (sl *SkipList[K, V]) Contains(key K) bool {
+	_, ok := sl.Get(key)
+	return ok
+}
+
+// Rank finds the k-th smallest element (1-based index)
+// Complexity: O(log(n)) expected
+func (sl *SkipList[K, V]) Rank(k int) (val V, ok bool) {
+	if k <= 0 || k > sl.size {
+		return val, false
+	}
+	x, traversed := sl.head, 0
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= k {
+			traversed += x.span[i]
+			x = x.next[i]
+		}
+		if traversed == k {
+			return x.val, true
+		}
+	}
+	return val, false
+}
+
+// Set inserts a value or updates if exists through compare function
+// Complexity: O(log(n)) expected
+func (sl *SkipList[K, V]) Set(key K, val V) {
+	// update[i] is the last node before key on level i, rank[i] is its position
+	var update [skipListMaxLevel]*_SkipListNode[K, V]
+	var rank [skipListMaxLevel]int
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && sl.cmp(x.next[i].key, key) < 0 {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	// renew value when equal
+	if next := x.next[0]; next != nil && sl.cmp(next.key, key) == 0 {
+		next.val = val
+		return
+	}
+	level := randomLevel()
+	for i := sl.level; i < level; i++ {
+		rank[i] = 0
+		update[i] = sl.head
+		update[i].span[i] = sl.size
+	}
+	sl.level = max(sl.level, level)
+	x = newSkipListNode(key, val, level)
+	for i := range level {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	// the higher levels step over the new node
+	for i := level; i < sl.level; i++ {
+		update[i].span[i]++
+	}
+	sl.size++
+}
+
+// Remove deletes a value from the list if exists
+// Complexity: O(log(n)) expected
+func (sl *SkipList[K, V]) Remove(key K) {
+	var update [skipListMaxLevel]*_SkipListNode[K, V]
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && sl.cmp(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	x = x.next[0]
+	if x == nil || sl.cmp(x.key, key) != 0 {
+		return
+	}
+	for i := range sl.level {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for sl.level > 1 && sl.head.next[sl.level-1] == nil {
+		sl.level--
+	}
+	sl.size--
+}
+
+// Iter provides an in-order traversal iterator
+func (sl *SkipList[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := sl.head.next[0]; x != nil; x = x.next[0] {
+			if !yield(x.key, x.val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package tree
+
+import (
+	"iter"
+)
+
+// SortedMap is an ordered map whose keys are sorted by a comparison function,
+// it is implemented by AvlTree, RedBlackTree and SkipList.
+type SortedMap[K, V any] interface {
+	// Size returns the total number of elements.
+	Size() int
+	// Get searches for a value and returns (value, true) if found.
+	Get(key K) (V, bool)
+	// Contains returns existence of a value.
+	Contains(key K) bool
+	// Rank finds the k-th smallest element (1-based index).
+	Rank(k int) (V, bool)
+	// Set inserts a value or updates if exists.
+	Set(key K, val V)
+	// Remove deletes a value if exists.
+	Remove(key K)
+	// Iter provides an in-order traversal iterator.
+	Iter() iter.Seq2[K, V]
+}
+
+var (
+	_ SortedMap[int, int] = (*AvlTree[int, int])(nil)
+	_ SortedMap[int, int] = (*RedBlackTree[int, int])(nil)
+	_ SortedMap[int, int] = (*SkipList[int, int])(nil)
+)
@@ -0,0 +1,203 @@
+package tree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var sortedMapImpls = []struct {
+	name string
+	new  func() SortedMap[int, int]
+}{
+	{"AvlTree", func() SortedMap[int, int] { return NewAvlTree[int, int](cmp.Compare) }},
+	{"RedBlackTree", func() SortedMap[int, int] { return NewRedBlackTree[int, int](cmp.Compare) }},
+	{"SkipList", func() SortedMap[int, int] { return NewSkipList[int, int](cmp.Compare) }},
+}
+
+// checkSortedMap compares the map with the sorted expected keys, and every value is key*10
+func checkSortedMap(t *testing.T, m SortedMap[int, int], expect []int, msg ...any) {
+	require.Equal(t, len(expect), m.Size(), msg...)
+	for i, k := range expect {
+		require.True(t, m.Contains(k), msg...)
+		v, ok := m.Get(k)
+		require.True(t, ok, msg...)
+		require.Equal(t, k*10, v, msg...)
+		v, ok = m.Rank(i + 1)
+		require.True(t, ok, msg...)
+		require.Equal(t, k*10, v, msg...)
+	}
+	_, ok := m.Rank(0)
+	require.False(t, ok, msg...)
+	_, ok = m.Rank(len(expect) + 1)
+	require.False(t, ok, msg...)
+	keys := make([]int, 0, len(expect))
+	for k, v := range m.Iter() {
+		require.Equal(t, k*10, v, msg...)
+		keys = append(keys, k)
+	}
+	require.Equal(t, expect, keys, msg...)
+}
+
+func TestSortedMap_Conformance(t *testing.T) {
+	for _, impl := range sortedMapImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			// empty
+			m := impl.new()
+			checkSortedMap(t, m, []int{})
+			m.Remove(1)
+			_, ok := m.Get(1)
+			require.False(t, ok)
+			require.False(t, m.Contains(1))
+
+			// update
+			m.Set(1, 0)
+			m.Set(1, 10)
+			checkSortedMap(t, m, []int{1})
+
+			// ascending, descending and removing all
+			m = impl.new()
+			for i := range 100 {
+				m.Set(i, i*10)
+			}
+			for i := 199; i >= 100; i-- {
+				m.Set(i, i*10)
+			}
+			expect := make([]int, 200)
+			for i := range expect {
+				expect[i] = i
+			}
+			checkSortedMap(t, m, expect)
+			for i := range 200 {
+				m.Remove(i)
+			}
+			checkSortedMap(t, m, []int{})
+
+			// random operations
+			r := rand.New(rand.NewSource(1))
+			m = impl.new()
+			expect = expect[:0]
+			for i := range 2000 {
+				key := r.Intn(300)
+				idx, found := slices.BinarySearch(expect, key)
+				if r.Intn(3) == 0 {
+					m.Remove(key)
+					if found {
+						expect = slices.Delete(expect, idx, idx+1)
+					}
+				} else {
+					m.Set(key, key*10)
+					if !found {
+						expect = slices.Insert(expect, idx, key)
+					}
+				}
+				if i%100 == 0 {
+					checkSortedMap(t, m, expect, i)
+				}
+			}
+			checkSortedMap(t, m, expect)
+
+			// break
+			for k := range m.Iter() {
+				if k > 100 {
+					break
+				}
+			}
+		})
+	}
+}
+
+// checkRedBlackTree validates the left-leaning red-black invariants and returns the black height
+func checkRedBlackTree[K, V any](t *testing.T, n *_RedBlackTreeNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	require.False(t, n.right.isRed(), "red right link")
+	require.False(t, n.isRed() && n.left.isRed(), "two red links in a row")
+	require.Equal(t, n.left.getSize()+n.right.getSize()+1, n.size)
+	lh, rh := checkRedBlackTree(t, n.left), checkRedBlackTree(t, n.right)
+	require.Equal(t, lh, rh, "unbalanced black height")
+	if n.isRed() {
+		return lh
+	}
+	return lh + 1
+}
+
+func TestRedBlackTree_Balance(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rb := NewRedBlackTree[int, int](cmp.Compare)
+	for i := range 3000 {
+		if key := r.Intn(500); i%3 == 2 {
+			rb.Remove(key)
+		} else {
+			rb.Set(key, key)
+		}
+		require.False(t, rb.root.isRed())
+		checkRedBlackTree(t, rb.root)
+	}
+}
+
+func BenchmarkSortedMap(b *testing.B) {
+	const n = int(1e4)
+	keys := rand.New(rand.NewSource(1)).Perm(n)
+	for _, impl := range sortedMapImpls {
+		b.Run(fmt.Sprintf("%s/Set", impl.name), func(b *testing.B) {
+			for range b.N {
+				m := impl.new()
+				for _, k := range keys {
+					m.Set(k, k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%s/Get", impl.name), func(b *testing.B) {
+			m := impl.new()
+			for _, k := range keys {
+				m.Set(k, k)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for _, k := range keys {
+					m.Get(k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%s/Rank", impl.name), func(b *testing.B) {
+			m := impl.new()
+			for _, k := range keys {
+				m.Set(k, k)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for _, k := range keys {
+					m.Rank(k + 1)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%s/SetRemove", impl.name), func(b *testing.B) {
+			for range b.N {
+				m := impl.new()
+				for _, k := range keys {
+					m.Set(k, k)
+				}
+				for _, k := range keys {
+					m.Remove(k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%s/Iter", impl.name), func(b *testing.B) {
+			m := impl.new()
+			for _, k := range keys {
+				m.Set(k, k)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for range m.Iter() {
+				}
+			}
+		})
+	}
+}
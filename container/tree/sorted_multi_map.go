@@ -0,0 +1,134 @@
+package tree
+
+import (
+	"iter"
+	"math"
+)
+
+// _MultiKey distinguishes duplicate keys by their insertion sequence
+type _MultiKey[K any] struct {
+	key K
+	seq uint64
+}
+
+// SortedMultiMap is an ordered map allowing duplicate keys based on AvlTree,
+// values of the same key are kept in insertion order.
+type SortedMultiMap[K, V any] struct {
+	avl *AvlTree[_MultiKey[K], V]
+	// seq is the next insertion sequence
+	seq uint64
+}
+
+// NewSortedMultiMap creates an empty SortedMultiMap with a given comparison function
+func NewSortedMultiMap[K, V any](cmp func(a, b K) int) *SortedMultiMap[K, V] {
+	return &SortedMultiMap[K, V]{
+		avl: NewAvlTree[_MultiKey[K], V](func(a, b _MultiKey[K]) int {
+			if c := cmp(a.key, b.key); c != 0 {
+				return c
+			}
+			switch {
+			case a.seq < b.seq:
+				return -1
+			case a.seq > b.seq:
+				return 1
+			}
+			return 0
+		}),
+		seq: 1,
+	}
+}
+
+// bounds returns the smallest and the largest possible multi keys of the key
+func bounds[K any](key K) (lo, hi _MultiKey[K]) {
+	return _MultiKey[K]{key: key, seq: 0}, _MultiKey[K]{key: key, seq: math.MaxUint64}
+}
+
+// Size returns the total number of elements, duplicates included.
+// Complexity: O(1)
+func (m *SortedMultiMap[K, V]) Size() int { return m.avl.Size() }
+
+// Count returns the number of values of the key
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) Count(key K) int { return m.avl.CountRange(bounds(key)) }
+
+// Contains returns existence of the key
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) Contains(key K) bool {
+	lo, hi := bounds(key)
+	mk, _, ok := m.avl.Ceiling(lo)
+	return ok && m.avl.cmp(mk, hi) <= 0
+}
+
+// Get returns the earliest inserted value of the key
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) Get(key K) (val V, ok bool) {
+	lo, hi := bounds(key)
+	mk, val, ok := m.avl.Ceiling(lo)
+	if !ok || m.avl.cmp(mk, hi) > 0 {
+		return val, false
+	}
+	return val, true
+}
+
+// GetAll returns all values of the key in insertion order
+// Complexity: O(log(n) + m), m is the number of values of the key
+func (m *SortedMultiMap[K, V]) GetAll(key K) []V {
+	lo, hi := bounds(key)
+	ret := make([]V, 0, m.avl.CountRange(lo, hi))
+	for _, v := range m.avl.Range(lo, hi, true, true) {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// Rank finds the k-th smallest element (1-based index), duplicates are counted separately
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) Rank(k int) (V, bool) { return m.avl.Rank(k) }
+
+// Select finds the k-th smallest element (1-based index) and returns its key and value
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) Select(k int) (key K, val V, ok bool) {
+	mk, val, ok := m.avl.Select(k)
+	return mk.key, val, ok
+}
+
+// Add appends a value to the key
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) Add(key K, val V) {
+	m.avl.Set(_MultiKey[K]{key: key, seq: m.seq}, val)
+	m.seq++
+}
+
+// RemoveOne deletes the earliest inserted value of the key, returns false if the key does not exist
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) RemoveOne(key K) bool {
+	lo, hi := bounds(key)
+	mk, _, ok := m.avl.Ceiling(lo)
+	if !ok || m.avl.cmp(mk, hi) > 0 {
+		return false
+	}
+	m.avl.Remove(mk)
+	return true
+}
+
+// RemoveAll deletes all values of the key and returns the number of deleted values
+// Complexity: O(log(n))
+func (m *SortedMultiMap[K, V]) RemoveAll(key K) int {
+	lo, hi := bounds(key)
+	less, rest := m.avl.Split(lo)
+	removed, greater := rest.Split(hi)
+	less.Join(greater)
+	m.avl = less
+	return removed.Size()
+}
+
+// Iter provides an in-order traversal iterator, values of the same key follow insertion order
+func (m *SortedMultiMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for mk, v := range m.avl.Iter() {
+			if !yield(mk.key, v) {
+				return
+			}
+		}
+	}
+}
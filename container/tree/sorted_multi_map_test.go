@@ -0,0 +1,64 @@
+package tree
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMultiMap(t *testing.T) {
+	m := NewSortedMultiMap[int, string](cmp.Compare)
+	require.False(t, m.Contains(1))
+	_, ok := m.Get(1)
+	require.False(t, ok)
+	require.False(t, m.RemoveOne(1))
+	require.Equal(t, 0, m.RemoveAll(1))
+
+	m.Add(2, "b1")
+	m.Add(1, "a1")
+	m.Add(2, "b2")
+	m.Add(3, "c1")
+	m.Add(2, "b3")
+	m.Add(1, "a2")
+	require.Equal(t, 6, m.Size())
+	require.Equal(t, 3, m.Count(2))
+	require.Equal(t, 0, m.Count(4))
+	require.True(t, m.Contains(3))
+	require.False(t, m.Contains(0))
+	v, ok := m.Get(2)
+	require.True(t, ok)
+	require.Equal(t, "b1", v)
+	require.Equal(t, []string{"b1", "b2", "b3"}, m.GetAll(2))
+	require.Equal(t, []string{}, m.GetAll(4))
+
+	// duplicates are counted by Rank
+	for i, e := range []string{"a1", "a2", "b1", "b2", "b3", "c1"} {
+		v, ok = m.Rank(i + 1)
+		require.True(t, ok)
+		require.Equal(t, e, v)
+		k, v, ok := m.Select(i + 1)
+		require.True(t, ok)
+		require.Equal(t, e, v)
+		require.Equal(t, int(e[0]-'a'+1), k)
+	}
+	_, ok = m.Rank(7)
+	require.False(t, ok)
+
+	require.True(t, m.RemoveOne(2))
+	require.Equal(t, []string{"b2", "b3"}, m.GetAll(2))
+	require.Equal(t, 2, m.RemoveAll(2))
+	require.False(t, m.Contains(2))
+	require.Equal(t, 3, m.Size())
+
+	keys, vals := make([]int, 0), make([]string, 0)
+	for k, v := range m.Iter() {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	}
+	require.Equal(t, []int{1, 1, 3}, keys)
+	require.Equal(t, []string{"a1", "a2", "c1"}, vals)
+	for range m.Iter() {
+		break
+	}
+}
@@ -1,33 +1,106 @@
 package tree
 
-type _TrieNode[T comparable] struct {
-	cnt      int
-	children map[T]*_TrieNode[T]
+import (
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/xianlianghe0123/goutils/container/queue"
+)
+
+// _TrieNode is shared by Trie and TrieMap, a sequence ends at the node if cnt > 0.
+// Trie keeps the count in cnt, while TrieMap sets cnt to 1 and keeps the value in val.
+type _TrieNode[T comparable, V any] struct {
+	cnt int
+	// total is the sum of counts in the subtree
+	total    int
+	val      V
+	children map[T]*_TrieNode[T, V]
+}
+
+// find returns the last node satisfy the prefix.
+func (n *_TrieNode[T, V]) find(prefix []T) *_TrieNode[T, V] {
+	for i := range prefix {
+		if n.children[prefix[i]] == nil {
+			return nil
+		}
+		n = n.children[prefix[i]]
+	}
+	return n
+}
+
+// walk recursively traverses the subtree, path is the sequence of n and is reused as a buffer.
+// The children are visited in the order of cmp, or random order if cmp is nil.
+// Every yielded sequence is a new slice.
+func (n *_TrieNode[T, V]) walk(path []T, cmp func(a, b T) int, yield func([]T, *_TrieNode[T, V]) bool) bool {
+	if n.cnt > 0 && !yield(slices.Clone(path), n) {
+		return false
+	}
+	if cmp == nil {
+		for e, child := range n.children {
+			if !child.walk(append(path, e), cmp, yield) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, e := range slices.SortedFunc(maps.Keys(n.children), cmp) {
+		if !n.children[e].walk(append(path, e), cmp, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// longestPrefixOf returns the longest sequence in the subtree which is a prefix of seq, and its node.
+// The prefix is capped so that appending to it never overwrites seq.
+func (n *_TrieNode[T, V]) longestPrefixOf(seq []T) (prefix []T, match *_TrieNode[T, V]) {
+	if n.cnt > 0 {
+		prefix, match = seq[:0:0], n
+	}
+	for i := range seq {
+		if n = n.children[seq[i]]; n == nil {
+			break
+		}
+		if n.cnt > 0 {
+			prefix, match = seq[:i+1:i+1], n
+		}
+	}
+	return prefix, match
 }
 
 // Trie is a generic prefix tree data structure.
 type Trie[T comparable] struct {
-	root *_TrieNode[T]
+	root *_TrieNode[T, struct{}]
+	// size is the number of distinct sequences
+	size int
 }
 
 // NewTrie creates a new instance of the Trie tree.
 func NewTrie[T comparable]() *Trie[T] {
 	return &Trie[T]{
-		root: new(_TrieNode[T]),
+		root: new(_TrieNode[T, struct{}]),
 	}
 }
 
 // Add inserts a sequence of elements into the Trie with a given count.
 func (t *Trie[T]) Add(cnt int, seq ...T) {
 	n := t.root
+	n.total += cnt
 	for i := range seq {
 		if n.children[seq[i]] == nil {
 			if n.children == nil {
-				n.children = make(map[T]*_TrieNode[T])
+				n.children = make(map[T]*_TrieNode[T, struct{}])
 			}
-			n.children[seq[i]] = new(_TrieNode[T])
+			n.children[seq[i]] = new(_TrieNode[T, struct{}])
 		}
 		n = n.children[seq[i]]
+		n.total += cnt
+	}
+	if n.cnt <= 0 && n.cnt+cnt > 0 {
+		t.size++
+	} else if n.cnt > 0 && n.cnt+cnt <= 0 {
+		t.size--
 	}
 	n.cnt += cnt
 }
@@ -37,7 +110,7 @@ func (t *Trie[T]) Add(cnt int, seq ...T) {
 // Or the count is decremented. After that, if the sequence count is less than or 0,
 // the entire sequence is removed too.
 func (t *Trie[T]) Remove(cnt int, seq ...T) {
-	nodes := make([]*_TrieNode[T], 0, len(seq))
+	nodes := make([]*_TrieNode[T, struct{}], 0, len(seq))
 	n := t.root
 	for i := range seq {
 		if n.children[seq[i]] == nil {
@@ -52,9 +125,15 @@ func (t *Trie[T]) Remove(cnt int, seq ...T) {
 	// remove partially
 	if 0 < cnt && cnt < n.cnt {
 		n.cnt -= cnt
+		t.subtractTotal(nodes, n, cnt)
 		return
 	}
 	// remove all
+	t.subtractTotal(nodes, n, n.cnt)
+	// a sequence with a non-positive count is not counted in size
+	if n.cnt > 0 {
+		t.size--
+	}
 	n.cnt = 0
 	for i := len(seq) - 1; i >= 0; i-- {
 		if len(n.children) > 0 || n.cnt > 0 {
@@ -65,9 +144,83 @@ func (t *Trie[T]) Remove(cnt int, seq ...T) {
 	}
 }
 
+// subtractTotal decreases the totals of the node n and its ancestors.
+func (t *Trie[T]) subtractTotal(ancestors []*_TrieNode[T, struct{}], n *_TrieNode[T, struct{}], cnt int) {
+	for _, a := range ancestors {
+		a.total -= cnt
+	}
+	n.total -= cnt
+}
+
+// Size returns the number of distinct sequences in the Trie.
+// @Complexity O(1)
+func (t *Trie[T]) Size() int { return t.size }
+
+// CountPrefix returns the total count of the sequences starting with the prefix.
+// @Complexity O(len(prefix))
+func (t *Trie[T]) CountPrefix(prefix ...T) int {
+	n := t.root.find(prefix)
+	if n == nil {
+		return 0
+	}
+	return n.total
+}
+
+// _TrieCandidate is a pending subtree or sequence during TopK
+type _TrieCandidate[T comparable] struct {
+	node *_TrieNode[T, struct{}]
+	path []T
+	// terminal means the candidate is the sequence of node itself rather than its subtree
+	terminal bool
+}
+
+func (c _TrieCandidate[T]) priority() int {
+	if c.terminal {
+		return c.node.cnt
+	}
+	return c.node.total
+}
+
+// TopK returns an iterator over at most k sequences starting with the prefix in descending order of count,
+// every yielded sequence is a new slice owned by the consumer.
+// It expands subtrees in the order of their totals, so only the necessary part of the Trie is visited.
+func (t *Trie[T]) TopK(prefix []T, k int) iter.Seq2[[]T, int] {
+	return func(yield func([]T, int) bool) {
+		n := t.root.find(prefix)
+		if n == nil || k <= 0 {
+			return
+		}
+		pq := queue.NewPriorityQueue(func(a, b _TrieCandidate[T]) bool {
+			if pa, pb := a.priority(), b.priority(); pa != pb {
+				return pa > pb
+			}
+			// the sequence goes first, since nothing remained can be greater
+			return a.terminal && !b.terminal
+		})
+		pq.Push(_TrieCandidate[T]{node: n, path: slices.Clone(prefix)})
+		for cnt := 0; cnt < k && !pq.IsEmpty(); {
+			c := pq.Pop()
+			if c.terminal {
+				if !yield(c.path, c.node.cnt) {
+					return
+				}
+				cnt++
+				continue
+			}
+			if c.node.cnt > 0 {
+				pq.Push(_TrieCandidate[T]{node: c.node, path: c.path, terminal: true})
+			}
+			for e, child := range c.node.children {
+				// force copying the path for every child
+				pq.Push(_TrieCandidate[T]{node: child, path: append(c.path[:len(c.path):len(c.path)], e)})
+			}
+		}
+	}
+}
+
 // Find searches for a sequence of elements in the Trie and returns its count.
 func (t *Trie[T]) Find(seq ...T) int {
-	n := t.find(seq)
+	n := t.root.find(seq)
 	if n == nil {
 		return 0
 	}
@@ -76,42 +229,111 @@ func (t *Trie[T]) Find(seq ...T) int {
 
 // HasPrefix checks if the Trie has the prefix.
 func (t *Trie[T]) HasPrefix(prefix ...T) bool {
-	n := t.find(prefix)
+	n := t.root.find(prefix)
 	return n != nil
 }
 
 // ForEach iterates over all sequences in the Trie and applies a consumer function.
 func (t *Trie[T]) ForEach(consume func(seq []T, cnt int)) {
-	t.forEach(t.root, nil, consume)
+	for seq, cnt := range t.All() {
+		consume(seq, cnt)
+	}
 }
 
 // ForEachPrefix iterates over all sequences in the Trie that start with a given prefix.
 func (t *Trie[T]) ForEachPrefix(prefix []T, consume func(seq []T, cnt int)) {
-	n := t.find(prefix)
-	t.forEach(n, prefix, consume)
+	for seq, cnt := range t.WithPrefix(prefix) {
+		consume(seq, cnt)
+	}
 }
 
-// find returns the last node satisfy the prefix.
-func (t *Trie[T]) find(prefix []T) *_TrieNode[T] {
-	n := t.root
-	for i := range prefix {
-		if n.children[prefix[i]] == nil {
-			return nil
+// All returns an iterator over all sequences and their counts in random order,
+// every yielded sequence is a new slice owned by the consumer.
+func (t *Trie[T]) All() iter.Seq2[[]T, int] { return t.WithPrefix(nil) }
+
+// WithPrefix returns an iterator over the sequences starting with the prefix in random order,
+// every yielded sequence is a new slice owned by the consumer.
+func (t *Trie[T]) WithPrefix(prefix []T) iter.Seq2[[]T, int] { return t.SortedWithPrefix(prefix, nil) }
+
+// SortedWithPrefix returns an iterator over the sequences starting with the prefix in lexicographical order
+// defined by cmp, every yielded sequence is a new slice owned by the consumer.
+// A nil cmp means random order.
+func (t *Trie[T]) SortedWithPrefix(prefix []T, cmp func(a, b T) int) iter.Seq2[[]T, int] {
+	return func(yield func([]T, int) bool) {
+		n := t.root.find(prefix)
+		if n == nil {
+			return
 		}
-		n = n.children[prefix[i]]
+		n.walk(slices.Clone(prefix), cmp, func(seq []T, n *_TrieNode[T, struct{}]) bool { return yield(seq, n.cnt) })
 	}
-	return n
 }
 
-// forEach recursively traverses the Trie tree and applies a consumer function.
-func (t *Trie[T]) forEach(n *_TrieNode[T], prefix []T, consume func(seq []T, cnt int)) {
+// LongestPrefixOf returns the longest sequence in the Trie which is a prefix of seq, and its count.
+func (t *Trie[T]) LongestPrefixOf(seq ...T) (prefix []T, cnt int, ok bool) {
+	prefix, n := t.root.longestPrefixOf(seq)
 	if n == nil {
-		return
+		return nil, 0, false
 	}
-	if n.cnt > 0 {
-		consume(prefix, n.cnt)
+	return prefix, n.cnt, true
+}
+
+// TrieFuzzyMatch is a sequence found by FuzzyFind with its edit distance and count.
+type TrieFuzzyMatch[T comparable] struct {
+	Seq      []T
+	Distance int
+	Count    int
+}
+
+// FuzzyFind returns an iterator over the sequences whose Levenshtein distance to seq is at most maxDistance.
+// It keeps a row of the distance matrix per node, and prunes the subtree once the whole row exceeds maxDistance.
+// Every yielded sequence is a new slice owned by the consumer.
+func (t *Trie[T]) FuzzyFind(maxDistance int, seq ...T) iter.Seq[TrieFuzzyMatch[T]] {
+	return func(yield func(TrieFuzzyMatch[T]) bool) {
+		// row[j] is the distance between the path and seq[:j]
+		row := make([]int, len(seq)+1)
+		for j := range row {
+			row[j] = j
+		}
+		if row[len(seq)] <= maxDistance && t.root.cnt > 0 {
+			if !yield(TrieFuzzyMatch[T]{Seq: []T{}, Distance: row[len(seq)], Count: t.root.cnt}) {
+				return
+			}
+		}
+		for e, child := range t.root.children {
+			if !t.fuzzyFind(child, []T{e}, seq, row, maxDistance, yield) {
+				return
+			}
+		}
+	}
+}
+
+// fuzzyFind calculates the row of n from the row of its parent and visits the subtree.
+func (t *Trie[T]) fuzzyFind(n *_TrieNode[T, struct{}], path, seq []T, prev []int, maxDistance int,
+	yield func(TrieFuzzyMatch[T]) bool) bool {
+	e := path[len(path)-1]
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	minDistance := row[0]
+	for j := 1; j < len(row); j++ {
+		replace := prev[j-1]
+		if seq[j-1] != e {
+			replace++
+		}
+		row[j] = min(prev[j]+1, row[j-1]+1, replace)
+		minDistance = min(minDistance, row[j])
+	}
+	if minDistance > maxDistance {
+		return true
+	}
+	if row[len(seq)] <= maxDistance && n.cnt > 0 {
+		if !yield(TrieFuzzyMatch[T]{Seq: slices.Clone(path), Distance: row[len(seq)], Count: n.cnt}) {
+			return false
+		}
 	}
 	for e, child := range n.children {
-		t.forEach(child, append(prefix, e), consume)
+		if !t.fuzzyFind(child, append(path, e), seq, row, maxDistance, yield) {
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,129 @@
+package tree
+
+import (
+	"iter"
+	"slices"
+)
+
+// TrieMap is a generic prefix tree mapping sequences to values.
+// It shares the node layout with Trie, where the count of a stored sequence is always 1.
+type TrieMap[T comparable, V any] struct {
+	root *_TrieNode[T, V]
+}
+
+// NewTrieMap creates a new instance of the TrieMap.
+func NewTrieMap[T comparable, V any]() *TrieMap[T, V] {
+	return &TrieMap[T, V]{
+		root: new(_TrieNode[T, V]),
+	}
+}
+
+// Size returns the number of sequences in the TrieMap.
+// @Complexity O(1)
+func (t *TrieMap[T, V]) Size() int { return t.root.total }
+
+// Put associates the value with a sequence of elements, the old value is replaced if exists.
+// @Complexity O(len(seq))
+func (t *TrieMap[T, V]) Put(val V, seq ...T) {
+	if n := t.root.find(seq); n != nil && n.cnt > 0 {
+		n.val = val
+		return
+	}
+	n := t.root
+	n.total++
+	for i := range seq {
+		if n.children[seq[i]] == nil {
+			if n.children == nil {
+				n.children = make(map[T]*_TrieNode[T, V])
+			}
+			n.children[seq[i]] = new(_TrieNode[T, V])
+		}
+		n = n.children[seq[i]]
+		n.total++
+	}
+	n.val, n.cnt = val, 1
+}
+
+// Get returns the value of a sequence of elements and whether it exists.
+// @Complexity O(len(seq))
+func (t *TrieMap[T, V]) Get(seq ...T) (val V, ok bool) {
+	n := t.root.find(seq)
+	if n == nil || n.cnt == 0 {
+		return val, false
+	}
+	return n.val, true
+}
+
+// HasPrefix checks if the TrieMap has the prefix.
+// @Complexity O(len(prefix))
+func (t *TrieMap[T, V]) HasPrefix(prefix ...T) bool {
+	n := t.root.find(prefix)
+	return n != nil && n.total > 0
+}
+
+// Delete removes a sequence of elements, returns false if it does not exist.
+// @Complexity O(len(seq))
+func (t *TrieMap[T, V]) Delete(seq ...T) bool {
+	n := t.root.find(seq)
+	if n == nil || n.cnt == 0 {
+		return false
+	}
+	var zero V
+	n.val, n.cnt = zero, 0
+	t.shrink(seq, 1)
+	return true
+}
+
+// DeletePrefix removes all sequences starting with the prefix and returns the number of removed ones.
+// @Complexity O(len(prefix))
+func (t *TrieMap[T, V]) DeletePrefix(prefix ...T) int {
+	n := t.root.find(prefix)
+	if n == nil || n.total == 0 {
+		return 0
+	}
+	removed := n.total
+	var zero V
+	n.val, n.cnt, n.children = zero, 0, nil
+	t.shrink(prefix, removed)
+	return removed
+}
+
+// shrink decreases the totals along the path by cnt, and cuts the first node becoming empty.
+func (t *TrieMap[T, V]) shrink(path []T, cnt int) {
+	n := t.root
+	n.total -= cnt
+	for i := range path {
+		child := n.children[path[i]]
+		if child.total -= cnt; child.total == 0 {
+			delete(n.children, path[i])
+			return
+		}
+		n = child
+	}
+}
+
+// All returns an iterator over all sequences and their values in random order,
+// every yielded sequence is a new slice owned by the consumer.
+func (t *TrieMap[T, V]) All() iter.Seq2[[]T, V] { return t.WithPrefix(nil) }
+
+// WithPrefix returns an iterator over the sequences starting with the prefix in random order,
+// every yielded sequence is a new slice owned by the consumer.
+func (t *TrieMap[T, V]) WithPrefix(prefix []T) iter.Seq2[[]T, V] {
+	return func(yield func([]T, V) bool) {
+		n := t.root.find(prefix)
+		if n == nil {
+			return
+		}
+		n.walk(slices.Clone(prefix), nil, func(seq []T, n *_TrieNode[T, V]) bool { return yield(seq, n.val) })
+	}
+}
+
+// LongestPrefixOf returns the longest sequence in the TrieMap which is a prefix of seq, and its value.
+// @Complexity O(len(seq))
+func (t *TrieMap[T, V]) LongestPrefixOf(seq ...T) (prefix []T, val V, ok bool) {
+	prefix, n := t.root.longestPrefixOf(seq)
+	if n == nil {
+		return nil, val, false
+	}
+	return prefix, n.val, true
+}
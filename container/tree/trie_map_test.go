@@ -0,0 +1,91 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrieMap(t *testing.T) {
+	tm := NewTrieMap[string, string]()
+	path := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, "/")
+	}
+	for _, p := range []string{"api", "api/v1/users", "api/v1/orders", "api/v2/users", "static"} {
+		tm.Put("handler:"+p, path(p)...)
+	}
+	tm.Put("users", path("api/v1/users")...)
+	require.Equal(t, 5, tm.Size())
+
+	v, ok := tm.Get(path("api/v1/users")...)
+	require.True(t, ok)
+	require.Equal(t, "users", v)
+	_, ok = tm.Get(path("api/v1")...)
+	require.False(t, ok)
+	_, ok = tm.Get(path("home")...)
+	require.False(t, ok)
+	require.True(t, tm.HasPrefix(path("api/v1")...))
+	require.False(t, tm.HasPrefix(path("home")...))
+
+	// longest prefix
+	prefix, v, ok := tm.LongestPrefixOf(path("api/v1/users/42")...)
+	require.True(t, ok)
+	require.Equal(t, path("api/v1/users"), prefix)
+	require.Equal(t, "users", v)
+	prefix, v, ok = tm.LongestPrefixOf(path("api/v3")...)
+	require.True(t, ok)
+	require.Equal(t, path("api"), prefix)
+	require.Equal(t, "handler:api", v)
+	_, _, ok = tm.LongestPrefixOf(path("home")...)
+	require.False(t, ok)
+	// the prefix does not share the storage with the input
+	seq := path("api/v3/items")
+	prefix, _, _ = tm.LongestPrefixOf(seq...)
+	_ = append(prefix, "admin")
+	require.Equal(t, path("api/v3/items"), seq)
+
+	// prefix iteration
+	m := make(map[string]string)
+	for seq, v := range tm.WithPrefix(path("api/v1")) {
+		m[strings.Join(seq, "/")] = v
+	}
+	require.Equal(t, map[string]string{"api/v1/users": "users", "api/v1/orders": "handler:api/v1/orders"}, m)
+	for range tm.WithPrefix(path("home")) {
+		require.Fail(t, "prefix not exist")
+	}
+
+	// delete
+	require.False(t, tm.Delete(path("api/v1")...))
+	require.True(t, tm.Delete(path("api/v1/orders")...))
+	require.False(t, tm.Delete(path("api/v1/orders")...))
+	require.Equal(t, 4, tm.Size())
+	require.True(t, tm.HasPrefix(path("api/v1")...))
+	tm.Put("admin", path("api/v1/admin")...)
+	require.Equal(t, 2, tm.DeletePrefix(path("api/v1")...))
+	require.Equal(t, 3, tm.Size())
+	require.False(t, tm.HasPrefix(path("api/v1")...))
+	require.True(t, tm.HasPrefix(path("api/v2")...))
+	require.Equal(t, 0, tm.DeletePrefix(path("api/v1")...))
+
+	m = make(map[string]string)
+	for seq, v := range tm.All() {
+		m[strings.Join(seq, "/")] = v
+	}
+	require.Equal(t, map[string]string{"api": "handler:api", "api/v2/users": "handler:api/v2/users", "static": "handler:static"}, m)
+	for range tm.All() {
+		break
+	}
+
+	// empty sequence and delete all
+	tm.Put("root")
+	_, v, ok = tm.LongestPrefixOf(path("home")...)
+	require.True(t, ok)
+	require.Equal(t, "root", v)
+	require.Equal(t, 4, tm.DeletePrefix())
+	require.Equal(t, 0, tm.Size())
+	require.False(t, tm.HasPrefix())
+}
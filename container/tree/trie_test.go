@@ -1,6 +1,7 @@
 package tree
 
 import (
+	"cmp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -68,3 +69,210 @@ func TestTrie(t *testing.T) {
 	trie.ForEachPrefix([]byte("x"), func(data []byte, cnt int) { cnt++ })
 	require.Equal(t, 0, cnt)
 }
+
+func TestTrie_Iter(t *testing.T) {
+	trie := NewTrie[byte]()
+	for _, s := range []string{"b", "ab", "abc", "a", "abd", "ba"} {
+		trie.Add(len(s), []byte(s)...)
+	}
+	// sequences are owned by the consumer
+	seqs := make([][]byte, 0)
+	m := make(map[string]int)
+	for seq, cnt := range trie.All() {
+		seqs = append(seqs, seq)
+		m[string(seq)] = cnt
+	}
+	require.Equal(t, map[string]int{"a": 1, "ab": 2, "abc": 3, "abd": 3, "b": 1, "ba": 2}, m)
+	for _, seq := range seqs {
+		require.Contains(t, m, string(seq))
+	}
+	retained := make([][]byte, 0)
+	trie.ForEach(func(seq []byte, _ int) { retained = append(retained, seq) })
+	got := make([]string, 0)
+	for _, seq := range retained {
+		got = append(got, string(seq))
+	}
+	require.ElementsMatch(t, []string{"a", "ab", "abc", "abd", "b", "ba"}, got)
+
+	// sorted
+	got = got[:0]
+	for seq := range trie.SortedWithPrefix(nil, cmp.Compare[byte]) {
+		got = append(got, string(seq))
+	}
+	require.Equal(t, []string{"a", "ab", "abc", "abd", "b", "ba"}, got)
+	got = got[:0]
+	for seq := range trie.SortedWithPrefix([]byte("ab"), func(a, b byte) int { return cmp.Compare(b, a) }) {
+		got = append(got, string(seq))
+	}
+	require.Equal(t, []string{"ab", "abd", "abc"}, got)
+
+	// prefix is not modified
+	prefix := make([]byte, 1, 8)
+	prefix[0] = 'a'
+	for seq := range trie.WithPrefix(prefix) {
+		require.Equal(t, byte('a'), seq[0])
+	}
+	require.Equal(t, byte(0), prefix[:2][1])
+	for range trie.WithPrefix([]byte("x")) {
+		require.Fail(t, "prefix not exist")
+	}
+	// break
+	for range trie.All() {
+		break
+	}
+	for range trie.SortedWithPrefix(nil, cmp.Compare[byte]) {
+		break
+	}
+}
+
+func TestTrie_LongestPrefixOf(t *testing.T) {
+	trie := NewTrie[string]()
+	route := func(s ...string) []string { return s }
+	trie.Add(1, route("api")...)
+	trie.Add(2, route("api", "v1", "users")...)
+	trie.Add(3, route("static")...)
+
+	prefix, cnt, ok := trie.LongestPrefixOf(route("api", "v1", "users", "42")...)
+	require.True(t, ok)
+	require.Equal(t, route("api", "v1", "users"), prefix)
+	require.Equal(t, 2, cnt)
+	prefix, cnt, ok = trie.LongestPrefixOf(route("api", "v1", "orders")...)
+	require.True(t, ok)
+	require.Equal(t, route("api"), prefix)
+	require.Equal(t, 1, cnt)
+	_, _, ok = trie.LongestPrefixOf(route("home")...)
+	require.False(t, ok)
+	_, _, ok = trie.LongestPrefixOf()
+	require.False(t, ok)
+	// empty sequence
+	trie.Add(1)
+	prefix, cnt, ok = trie.LongestPrefixOf(route("home")...)
+	require.True(t, ok)
+	require.Empty(t, prefix)
+	require.Equal(t, 1, cnt)
+	// the prefix does not share the storage with the input
+	seq := route("api", "v1", "orders")
+	prefix, _, _ = trie.LongestPrefixOf(seq...)
+	_ = append(prefix, "admin")
+	require.Equal(t, route("api", "v1", "orders"), seq)
+	prefix, _, _ = trie.LongestPrefixOf(seq[:2]...)
+	_ = append(prefix, "admin")
+	require.Equal(t, route("api", "v1", "orders"), seq)
+}
+
+func TestTrie_Count(t *testing.T) {
+	trie := NewTrie[byte]()
+	require.Equal(t, 0, trie.Size())
+	for s, cnt := range map[string]int{"car": 5, "card": 2, "care": 7, "cat": 3, "dog": 4, "do": 1} {
+		trie.Add(cnt, []byte(s)...)
+	}
+	trie.Add(1, []byte("car")...)
+	require.Equal(t, 6, trie.Size())
+	require.Equal(t, 23, trie.CountPrefix())
+	require.Equal(t, 18, trie.CountPrefix([]byte("ca")...))
+	require.Equal(t, 15, trie.CountPrefix([]byte("car")...))
+	require.Equal(t, 0, trie.CountPrefix([]byte("x")...))
+
+	trie.Remove(2, []byte("care")...)
+	require.Equal(t, 13, trie.CountPrefix([]byte("car")...))
+	trie.Remove(0, []byte("card")...)
+	require.Equal(t, 11, trie.CountPrefix([]byte("car")...))
+	require.Equal(t, 5, trie.Size())
+	trie.Remove(0, []byte("card")...)
+	require.Equal(t, 5, trie.Size())
+	require.Equal(t, 19, trie.CountPrefix())
+}
+
+func TestTrie_CountNonPositive(t *testing.T) {
+	trie := NewTrie[byte]()
+	trie.Add(-1, 'x')
+	require.Equal(t, 0, trie.Size())
+	trie.Remove(0, 'x')
+	require.Equal(t, 0, trie.Size())
+	require.False(t, trie.HasPrefix('x'))
+
+	trie.Add(2, []byte("ab")...)
+	trie.Add(-2, []byte("ab")...)
+	require.Equal(t, 0, trie.Size())
+	trie.Remove(0, []byte("ab")...)
+	require.Equal(t, 0, trie.Size())
+	require.Equal(t, 0, trie.CountPrefix())
+
+	trie.Add(1, []byte("ab")...)
+	require.Equal(t, 1, trie.Size())
+	trie.Remove(1, []byte("ab")...)
+	require.Equal(t, 0, trie.Size())
+}
+
+func TestTrie_TopK(t *testing.T) {
+	trie := NewTrie[byte]()
+	for s, cnt := range map[string]int{"car": 6, "card": 2, "care": 7, "cat": 3, "dog": 4, "do": 1, "c": 5} {
+		trie.Add(cnt, []byte(s)...)
+	}
+	type pair struct {
+		seq string
+		cnt int
+	}
+	topK := func(prefix string, k int) []pair {
+		ret := make([]pair, 0)
+		for seq, cnt := range trie.TopK([]byte(prefix), k) {
+			ret = append(ret, pair{string(seq), cnt})
+		}
+		return ret
+	}
+	require.Equal(t, []pair{{"care", 7}, {"car", 6}, {"c", 5}}, topK("c", 3))
+	require.Equal(t, []pair{{"care", 7}, {"car", 6}, {"card", 2}}, topK("car", 10))
+	require.Equal(t, []pair{{"care", 7}, {"car", 6}, {"c", 5}, {"dog", 4}, {"cat", 3}, {"card", 2}, {"do", 1}}, topK("", 10))
+	require.Empty(t, topK("x", 3))
+	require.Empty(t, topK("c", 0))
+	for range trie.TopK(nil, 3) {
+		break
+	}
+}
+
+func levenshtein(a, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := range a {
+		prev := row[0]
+		row[0] = i + 1
+		for j := range b {
+			cur := row[j+1]
+			replace := prev
+			if a[i] != b[j] {
+				replace++
+			}
+			row[j+1] = min(row[j+1]+1, row[j]+1, replace)
+			prev = cur
+		}
+	}
+	return row[len(b)]
+}
+
+func TestTrie_FuzzyFind(t *testing.T) {
+	trie := NewTrie[rune]()
+	words := map[string]int{"status": 3, "stash": 2, "start": 1, "stop": 4, "commit": 5, "checkout": 1, "": 1}
+	for w, cnt := range words {
+		trie.Add(cnt, []rune(w)...)
+	}
+	for _, query := range []string{"statu", "stat", "comit", "chekout", "xyz", "", "s"} {
+		for maxDistance := range 4 {
+			expect := make(map[string][2]int)
+			for w, cnt := range words {
+				if d := levenshtein([]rune(w), []rune(query)); d <= maxDistance {
+					expect[w] = [2]int{d, cnt}
+				}
+			}
+			got := make(map[string][2]int)
+			for m := range trie.FuzzyFind(maxDistance, []rune(query)...) {
+				got[string(m.Seq)] = [2]int{m.Distance, m.Count}
+			}
+			require.Equal(t, expect, got, query, maxDistance)
+		}
+	}
+	for range trie.FuzzyFind(10, []rune("stat")...) {
+		break
+	}
+}
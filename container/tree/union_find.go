@@ -1,7 +1,13 @@
 package tree
 
+import (
+	"iter"
+)
+
 type UnionFind[T comparable] struct {
 	parent map[T]T
+	// size is the element count of the set, only valid for roots
+	size map[T]int
 	// connected component count
 	cnt int
 }
@@ -10,6 +16,7 @@ type UnionFind[T comparable] struct {
 func NewUnionFind[T comparable]() *UnionFind[T] {
 	uf := &UnionFind[T]{
 		parent: make(map[T]T),
+		size:   make(map[T]int),
 		cnt:    0,
 	}
 	return uf
@@ -19,25 +26,69 @@ func NewUnionFind[T comparable]() *UnionFind[T] {
 func (uf *UnionFind[T]) Find(e T) T {
 	if _, ok := uf.parent[e]; !ok {
 		uf.parent[e] = e
+		uf.size[e] = 1
 		uf.cnt++
+		return e
+	}
+	root := e
+	for uf.parent[root] != root {
+		root = uf.parent[root]
 	}
-	if uf.parent[e] != e {
-		uf.parent[e] = uf.Find(uf.parent[e])
+	// path compression
+	for e != root {
+		e, uf.parent[e] = uf.parent[e], root
 	}
-	return uf.parent[e]
+	return root
 }
 
 // IsConnect checks if two elements are in the same set.
 func (uf *UnionFind[T]) IsConnect(e1, e2 T) bool { return uf.Find(e1) == uf.Find(e2) }
 
-// Union merges the sets containing elements e1 and e2.
+// Union merges the sets containing elements e1 and e2,
+// the smaller set is attached to the larger one.
 func (uf *UnionFind[T]) Union(e1, e2 T) {
-	if uf.IsConnect(e1, e2) {
+	r1, r2 := uf.Find(e1), uf.Find(e2)
+	if r1 == r2 {
 		return
 	}
-	uf.parent[uf.Find(e1)] = uf.Find(e2)
+	if uf.size[r1] > uf.size[r2] {
+		r1, r2 = r2, r1
+	}
+	uf.parent[r1] = r2
+	uf.size[r2] += uf.size[r1]
+	delete(uf.size, r1)
 	uf.cnt--
 }
 
 // ConnectedComponent returns the number of connected components in the union-find.
 func (uf *UnionFind[T]) ConnectedComponent() int { return uf.cnt }
+
+// SizeOf returns the number of elements in the set containing e.
+func (uf *UnionFind[T]) SizeOf(e T) int { return uf.size[uf.Find(e)] }
+
+// Roots returns an iterator over the roots of all sets.
+func (uf *UnionFind[T]) Roots() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for root := range uf.size {
+			if !yield(root) {
+				return
+			}
+		}
+	}
+}
+
+// Groups returns an iterator over all sets, yielding the root and the members of each set.
+func (uf *UnionFind[T]) Groups() iter.Seq2[T, []T] {
+	return func(yield func(T, []T) bool) {
+		groups := make(map[T][]T, len(uf.size))
+		for e := range uf.parent {
+			root := uf.Find(e)
+			groups[root] = append(groups[root], e)
+		}
+		for root, members := range groups {
+			if !yield(root, members) {
+				return
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package tree
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -30,3 +31,46 @@ func TestUnionFind(t *testing.T) {
 
 	require.Equal(t, 3, uf.ConnectedComponent())
 }
+
+func TestUnionFind_Size(t *testing.T) {
+	uf := NewUnionFind[int]()
+	// long chain should not overflow the stack
+	for i := range 100000 {
+		uf.Union(i, i+1)
+	}
+	require.Equal(t, 1, uf.ConnectedComponent())
+	require.Equal(t, 100001, uf.SizeOf(0))
+	require.True(t, uf.IsConnect(0, 100000))
+
+	uf = NewUnionFind[int]()
+	for _, u := range [][]int{{1, 2}, {3, 4}, {2, 4}, {5, 6}} {
+		uf.Union(u[0], u[1])
+	}
+	uf.Find(7)
+	require.Equal(t, 4, uf.SizeOf(3))
+	require.Equal(t, 2, uf.SizeOf(6))
+	require.Equal(t, 1, uf.SizeOf(7))
+	// a new element
+	require.Equal(t, 1, uf.SizeOf(8))
+
+	roots := make(map[int]bool)
+	for root := range uf.Roots() {
+		require.Equal(t, root, uf.Find(root))
+		roots[root] = true
+	}
+	require.Len(t, roots, 4)
+
+	groups := make(map[int][]int)
+	for root, members := range uf.Groups() {
+		require.True(t, roots[root])
+		slices.Sort(members)
+		groups[members[0]] = members
+	}
+	require.Equal(t, map[int][]int{1: {1, 2, 3, 4}, 5: {5, 6}, 7: {7}, 8: {8}}, groups)
+	for range uf.Groups() {
+		break
+	}
+	for range uf.Roots() {
+		break
+	}
+}
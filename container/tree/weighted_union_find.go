@@ -0,0 +1,108 @@
+package tree
+
+// WeightedUnionFind is a union-find keeping the potential difference between elements of a set.
+// The weights form an abelian group given by (identity, merge, inverse).
+type WeightedUnionFind[T, W comparable] struct {
+	parent map[T]T
+	// potential is weight(e) - weight(parent(e))
+	potential map[T]W
+	// size is the element count of the set, only valid for roots
+	size map[T]int
+	// connected component count
+	cnt int
+
+	identity W
+	merge    func(W, W) W
+	inverse  func(W) W
+}
+
+// NewWeightedUnionFind creates and returns a new instance of WeightedUnionFind.
+// @Param identity is the identity weight.
+// @Param merge adds two weights, it must be associative and commutative.
+// @Param inverse returns the negative weight, so that merge(w, inverse(w)) is the identity.
+func NewWeightedUnionFind[T, W comparable](identity W, merge func(x, y W) W, inverse func(x W) W) *WeightedUnionFind[T, W] {
+	return &WeightedUnionFind[T, W]{
+		parent:    make(map[T]T),
+		potential: make(map[T]W),
+		size:      make(map[T]int),
+		cnt:       0,
+		identity:  identity,
+		merge:     merge,
+		inverse:   inverse,
+	}
+}
+
+// Find returns the root of the element e and weight(e) - weight(root).
+func (uf *WeightedUnionFind[T, W]) Find(e T) (root T, weight W) {
+	if _, ok := uf.parent[e]; !ok {
+		uf.parent[e] = e
+		uf.potential[e] = uf.identity
+		uf.size[e] = 1
+		uf.cnt++
+		return e, uf.identity
+	}
+	path := make([]T, 0)
+	for root = e; uf.parent[root] != root; root = uf.parent[root] {
+		path = append(path, root)
+	}
+	// path compression, accumulate the potential from the nearest to the root
+	weight = uf.identity
+	for i := len(path) - 1; i >= 0; i-- {
+		weight = uf.merge(uf.potential[path[i]], weight)
+		uf.potential[path[i]] = weight
+		uf.parent[path[i]] = root
+	}
+	return root, weight
+}
+
+// IsConnect checks if two elements are in the same set.
+func (uf *WeightedUnionFind[T, W]) IsConnect(e1, e2 T) bool {
+	r1, _ := uf.Find(e1)
+	r2, _ := uf.Find(e2)
+	return r1 == r2
+}
+
+// Diff returns weight(e2) - weight(e1) and true if they are in the same set.
+func (uf *WeightedUnionFind[T, W]) Diff(e1, e2 T) (W, bool) {
+	r1, w1 := uf.Find(e1)
+	r2, w2 := uf.Find(e2)
+	if r1 != r2 {
+		return uf.identity, false
+	}
+	return uf.merge(w2, uf.inverse(w1)), true
+}
+
+// Union records that weight(e2) - weight(e1) equals diff and merges their sets.
+// It returns false if the constraint contradicts the existing ones, which leaves the union-find unchanged.
+func (uf *WeightedUnionFind[T, W]) Union(e1, e2 T, diff W) bool {
+	r1, w1 := uf.Find(e1)
+	r2, w2 := uf.Find(e2)
+	if r1 == r2 {
+		return uf.merge(w2, uf.inverse(w1)) == diff
+	}
+	// weight(r2) - weight(r1) = diff + w1 - w2
+	rootDiff := uf.merge(uf.merge(diff, w1), uf.inverse(w2))
+	// attach the smaller set to the larger one
+	if uf.size[r1] > uf.size[r2] {
+		uf.parent[r2] = r1
+		uf.potential[r2] = rootDiff
+		uf.size[r1] += uf.size[r2]
+		delete(uf.size, r2)
+	} else {
+		uf.parent[r1] = r2
+		uf.potential[r1] = uf.inverse(rootDiff)
+		uf.size[r2] += uf.size[r1]
+		delete(uf.size, r1)
+	}
+	uf.cnt--
+	return true
+}
+
+// ConnectedComponent returns the number of connected components in the union-find.
+func (uf *WeightedUnionFind[T, W]) ConnectedComponent() int { return uf.cnt }
+
+// SizeOf returns the number of elements in the set containing e.
+func (uf *WeightedUnionFind[T, W]) SizeOf(e T) int {
+	root, _ := uf.Find(e)
+	return uf.size[root]
+}
@@ -0,0 +1,50 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedUnionFind(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 50
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = r.Intn(1000) - 500
+	}
+	uf := NewWeightedUnionFind[int, int](0, func(x, y int) int { return x + y }, func(x int) int { return -x })
+	for range 40 {
+		a, b := r.Intn(n), r.Intn(n)
+		require.True(t, uf.Union(a, b, weights[b]-weights[a]))
+	}
+	for a := range n {
+		for b := range n {
+			d, ok := uf.Diff(a, b)
+			require.Equal(t, uf.IsConnect(a, b), ok)
+			if ok {
+				require.Equal(t, weights[b]-weights[a], d, a, b)
+			}
+		}
+	}
+
+	uf = NewWeightedUnionFind[int, int](0, func(x, y int) int { return x + y }, func(x int) int { return -x })
+	// A is 3 units ahead of B, B is 2 units ahead of C
+	require.True(t, uf.Union('B', 'A', 3))
+	require.True(t, uf.Union('C', 'B', 2))
+	d, ok := uf.Diff('C', 'A')
+	require.True(t, ok)
+	require.Equal(t, 5, d)
+	d, ok = uf.Diff('A', 'C')
+	require.True(t, ok)
+	require.Equal(t, -5, d)
+	// consistent and contradictory constraints
+	require.True(t, uf.Union('A', 'C', -5))
+	require.False(t, uf.Union('A', 'C', 5))
+	_, ok = uf.Diff('A', 'D')
+	require.False(t, ok)
+	require.Equal(t, 2, uf.ConnectedComponent())
+	require.Equal(t, 3, uf.SizeOf('B'))
+	require.Equal(t, 1, uf.SizeOf('D'))
+}